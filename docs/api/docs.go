@@ -16,6 +16,55 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/v1/accounts/address/convert": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Проверка адреса и получение адресов аккаунта в других сетях",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bech32 или 0x адрес",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "префиксы сетей через запятую, по умолчанию все сети",
+                        "name": "prefixes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/account.ConvertAddressResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/v1/accounts/balance": {
             "get": {
                 "consumes": [
@@ -42,6 +91,12 @@ const docTemplate = `{
                         "name": "address",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "фиатная валюта, например usd",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -156,7 +211,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/accounts/restore": {
+        "/v1/accounts/portfolio": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -167,7 +222,7 @@ const docTemplate = `{
                 "tags": [
                     "accounts"
                 ],
-                "summary": "Получение аккаунта по ключу",
+                "summary": "Получить портфель по нескольким сетям",
                 "parameters": [
                     {
                         "description": "body",
@@ -175,7 +230,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/account.RestoreAccountInput"
+                            "$ref": "#/definitions/account.PortfolioInput"
                         }
                     }
                 ],
@@ -191,7 +246,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/account.KeyResponse"
+                                            "$ref": "#/definitions/account.PortfolioResponse"
                                         }
                                     }
                                 }
@@ -201,8 +256,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/chains": {
-            "get": {
+        "/v1/accounts/restore": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -210,9 +265,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chains"
+                    "accounts"
+                ],
+                "summary": "Получение аккаунта по ключу",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/account.RestoreAccountInput"
+                        }
+                    }
                 ],
-                "summary": "Получение данных о сетях",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -225,10 +291,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/chain.ShortResponse"
-                                            }
+                                            "$ref": "#/definitions/account.KeyResponse"
                                         }
                                     }
                                 }
@@ -238,8 +301,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/chains/{id}/validators": {
-            "get": {
+        "/v1/accounts/sign-arbitrary": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -247,28 +310,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chains"
+                    "accounts"
                 ],
-                "summary": "Получение данных о валидаторах",
+                "summary": "Подпись произвольных данных (ADR-036)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "chainId",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "кол-во валидаторов для запроса",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "кол-во валидаторов для пропуска",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/account.SignArbitraryInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -283,7 +336,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/chain.PagedValidatorsResponse"
+                                            "$ref": "#/definitions/account.SignArbitraryResponse"
                                         }
                                     }
                                 }
@@ -293,7 +346,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/transactions/send": {
+        "/v1/accounts/verify-arbitrary": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -302,9 +355,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "accounts"
                 ],
-                "summary": "Отправить транзакцию",
+                "summary": "Проверка подписи произвольных данных (ADR-036)",
                 "parameters": [
                     {
                         "description": "body",
@@ -312,7 +365,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/transaction.SendInput"
+                            "$ref": "#/definitions/account.VerifyArbitraryInput"
                         }
                     }
                 ],
@@ -328,7 +381,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/transaction.SendResponse"
+                                            "$ref": "#/definitions/account.VerifyArbitraryResponse"
                                         }
                                     }
                                 }
@@ -338,8 +391,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/transactions/send/firebase": {
-            "post": {
+        "/v1/admin/dead-letters": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -347,18 +400,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "admin"
                 ],
-                "summary": "Отправить транзакцию с подпиской на события тендерминта с пушами в firebase",
+                "summary": "Получение уведомлений, которые не удалось доставить",
                 "parameters": [
                     {
-                        "description": "body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/transaction.SendInputFirebase"
-                        }
+                        "type": "string",
+                        "description": "Bearer ADMIN_TOKEN",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -373,7 +424,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/transaction.SendResponseFirebase"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/delivery.Delivery"
+                                            }
                                         }
                                     }
                                 }
@@ -383,8 +437,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/transactions/simulate": {
-            "post": {
+        "/v1/admin/deliveries/{txHash}": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -392,18 +446,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "admin"
                 ],
-                "summary": "Симуляция транзакции для расчета параметров",
+                "summary": "Получение статуса доставки уведомлений по хэшу транзакции",
                 "parameters": [
                     {
-                        "description": "body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/transaction.SimulateInput"
-                        }
+                        "type": "string",
+                        "description": "Bearer ADMIN_TOKEN",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "хэш транзакции",
+                        "name": "txHash",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -418,7 +477,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/transaction.SimulateResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/delivery.Delivery"
+                                            }
                                         }
                                     }
                                 }
@@ -427,69 +489,1606 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "account.BalanceResponse": {
-            "type": "object",
-            "properties": {
-                "availableAmount": {
-                    "type": "string"
-                },
-                "stakedAmount": {
-                    "type": "string"
-                },
-                "totalAmount": {
-                    "type": "string"
+        },
+        "/v1/chains": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chains"
+                ],
+                "summary": "Получение данных о сетях",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/chain.ShortResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
                 }
             }
         },
-        "account.CreateAccountInput": {
-            "type": "object",
-            "properties": {
-                "accountPath": {
-                    "type": "integer"
-                },
-                "chainPrefixes": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        "/v1/chains/{id}/endpoints": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chains"
+                ],
+                "summary": "Получение состояния rpc эндпоинтов сети",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "chainId",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/chain.EndpointsHealth"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
-                },
-                "coinType": {
-                    "type": "integer"
-                },
-                "indexPath": {
-                    "type": "integer"
-                },
-                "mnemonic": {
-                    "type": "string"
                 }
             }
         },
-        "account.CreateMnemonicInput": {
-            "type": "object",
+        "/v1/chains/{id}/staking": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chains"
+                ],
+                "summary": "Получение параметров стейкинга и apr сети и валидаторов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "chainId",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/chain.StakingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/chains/{id}/validators": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chains"
+                ],
+                "summary": "Получение данных о валидаторах",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "chainId",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "кол-во валидаторов для запроса, 10 по умолчанию, не больше 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "кол-во валидаторов для пропуска",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "статус: bonded (по умолчанию), unbonding, unbonded, all",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "сортировка: voting_power (по умолчанию), commission, name, uptime",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "порядок сортировки: asc, desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "поиск по имени валидатора",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/chain.PagedValidatorsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/chains/{id}/validators/{address}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chains"
+                ],
+                "summary": "Получение подробных данных о валидаторе",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "chainId",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "адрес валидатора",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "адрес делегатора для получения его делегации",
+                        "name": "delegator",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/chain.ValidatorDetails"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/multisig/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "multisig"
+                ],
+                "summary": "Создание мультисиг аккаунта по публичным ключам",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/multisig.CreateAccountInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/multisig.AccountResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/multisig/tx/combine": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "multisig"
+                ],
+                "summary": "Сборка транзакции из частичных подписей",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/multisig.CombineTxInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/multisig.CombineTxResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/multisig/tx/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "multisig"
+                ],
+                "summary": "Создание неподписанной транзакции с мультисиг аккаунта",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/multisig.CreateTxInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/multisig.CreateTxResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/multisig/tx/sign": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "multisig"
+                ],
+                "summary": "Частичная подпись транзакции участником мультисига",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/multisig.SignTxInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/multisig.Signature"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/restake/grant": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restake"
+                ],
+                "summary": "Разрешить автоматическое реинвестирование наград через authz",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/restake.GrantInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/restake.GrantResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/restake/revoke": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restake"
+                ],
+                "summary": "Отозвать разрешение на автоматическое реинвестирование наград",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/restake.RevokeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/restake.RevokeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/restake/{chainId}/{address}/runs": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restake"
+                ],
+                "summary": "Получение истории реинвестирования наград делегатора",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "chainId",
+                        "name": "chainId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "адрес делегатора",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/restake.Run"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/send": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Отправить транзакцию",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transaction.SendInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/transaction.SendResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/send/firebase": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Отправить транзакцию с подпиской на события тендерминта и уведомлением (fcm, webhook, apns)",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transaction.SendInputFirebase"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/transaction.SendResponseFirebase"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/simulate": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Симуляция транзакции для расчета параметров",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transaction.SimulateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/transaction.SimulateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{chainId}/{hash}/events": {
+            "get": {
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Поток событий статуса транзакции (SSE): broadcast, mempool, included, success/failure/timeout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "chainId",
+                        "name": "chainId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "хэш транзакции",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transaction.TxStatusEvent"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/watches": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watches"
+                ],
+                "summary": "Подписаться на уведомления по адресу: входящие переводы, валидаторы, голосования",
+                "parameters": [
+                    {
+                        "description": "body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/watch.CreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/watch.Watch"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/watches/{id}": {
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watches"
+                ],
+                "summary": "Отписаться от уведомлений по адресу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id подписки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "получатель уведомлений, создавший подписку",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/watch.RemoveInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.apiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/watch.Watch"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "account.BalanceResponse": {
+            "type": "object",
+            "properties": {
+                "availableAmount": {
+                    "type": "string"
+                },
+                "availableFiat": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price is omitted with fiat values, when price of the asset is unknown.",
+                    "type": "number"
+                },
+                "stakedAmount": {
+                    "type": "string"
+                },
+                "stakedFiat": {
+                    "type": "string"
+                },
+                "totalAmount": {
+                    "type": "string"
+                },
+                "totalFiat": {
+                    "type": "string"
+                },
+                "verified": {
+                    "description": "Verified means that available amount is proven by light client at VerifiedHeight instead of the latest state.",
+                    "type": "boolean"
+                },
+                "verifiedHeight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "account.ChainPortfolio": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "availableAmount": {
+                    "type": "string"
+                },
+                "balances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.Coin"
+                    }
+                },
+                "chainId": {
+                    "type": "string"
+                },
+                "delegations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.Delegation"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price is omitted with fiat values, when price of the asset is unknown.",
+                    "type": "number"
+                },
+                "rewardsAmount": {
+                    "type": "string"
+                },
+                "stakedAmount": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "totalAmount": {
+                    "type": "string"
+                },
+                "totalFiat": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.Coin": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "denom": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.ConvertAddressResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.PrefixedAddress"
+                    }
+                },
+                "chainIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prefix": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.CreateAccountInput": {
+            "type": "object",
+            "properties": {
+                "accountPath": {
+                    "type": "integer"
+                },
+                "chainPrefixes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "coinType": {
+                    "type": "integer"
+                },
+                "indexPath": {
+                    "type": "integer"
+                },
+                "mnemonic": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.CreateMnemonicInput": {
+            "type": "object",
+            "properties": {
+                "mnemonicSize": {
+                    "type": "integer"
+                }
+            }
+        },
+        "account.Delegation": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "rewards": {
+                    "type": "string"
+                },
+                "validatorAddress": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.KeyResponse": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.PortfolioAccount": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "chainId": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.PortfolioInput": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.PortfolioAccount"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.PortfolioResponse": {
+            "type": "object",
+            "properties": {
+                "chains": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.ChainPortfolio"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "totalFiat": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.PrefixedAddress": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.PubKey": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.RestoreAccountInput": {
+            "type": "object",
+            "properties": {
+                "chainPrefixes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.SignArbitraryInput": {
+            "type": "object",
+            "properties": {
+                "chainPrefix": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.SignArbitraryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string"
+                },
+                "pubKey": {
+                    "$ref": "#/definitions/account.PubKey"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "signer": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.VerifyArbitraryInput": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string"
+                },
+                "pubKey": {
+                    "$ref": "#/definitions/account.PubKey"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "signer": {
+                    "type": "string"
+                }
+            }
+        },
+        "account.VerifyArbitraryResponse": {
+            "type": "object",
+            "properties": {
+                "isValid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "chain.EndpointHealth": {
+            "type": "object",
+            "properties": {
+                "catchingUp": {
+                    "type": "boolean"
+                },
+                "checkedAt": {
+                    "type": "string"
+                },
+                "circuitOpen": {
+                    "type": "boolean"
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "errorRate": {
+                    "type": "number"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "heightLag": {
+                    "type": "integer"
+                },
+                "isHealthy": {
+                    "type": "boolean"
+                },
+                "latencyMs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "chain.EndpointsHealth": {
+            "type": "object",
+            "properties": {
+                "chainId": {
+                    "type": "string"
+                },
+                "checkedAt": {
+                    "type": "string"
+                },
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/chain.EndpointHealth"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "healthy": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "chain.PagedValidatorsResponse": {
+            "type": "object",
             "properties": {
-                "mnemonicSize": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/chain.Validator"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "chain.ShortResponse": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string"
+                },
+                "bech32Prefix": {
+                    "type": "string"
+                },
+                "chainId": {
+                    "type": "string"
+                },
+                "chainName": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "display": {
+                    "type": "string"
+                },
+                "keyAlgos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "logoPngUrl": {
+                    "type": "string"
+                },
+                "logoSvgUrl": {
+                    "type": "string"
+                },
+                "prettyName": {
+                    "type": "string"
+                },
+                "slip44": {
+                    "type": "integer"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "chain.StakingResponse": {
+            "type": "object",
+            "properties": {
+                "actualBlocksPerYear": {
+                    "type": "integer"
+                },
+                "annualProvisions": {
+                    "type": "string"
+                },
+                "blocksPerYear": {
+                    "type": "integer"
+                },
+                "bondDenom": {
+                    "type": "string"
+                },
+                "bondedRatio": {
+                    "type": "string"
+                },
+                "bondedTokens": {
+                    "type": "string"
+                },
+                "communityTax": {
+                    "type": "string"
+                },
+                "inflation": {
+                    "type": "string"
+                },
+                "maxValidators": {
+                    "type": "integer"
+                },
+                "nominalApr": {
+                    "description": "NominalAPR is calculated with blocks per year from mint params.",
+                    "type": "string"
+                },
+                "realApr": {
+                    "description": "RealAPR is calculated with blocks per year measured by actual block time, it equals to NominalAPR,\nif block time can't be measured.",
+                    "type": "string"
+                },
+                "totalSupply": {
+                    "type": "string"
+                },
+                "unbondingTime": {
+                    "description": "UnbondingTime is in seconds.",
+                    "type": "integer"
+                },
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/chain.ValidatorAPR"
+                    }
+                }
+            }
+        },
+        "chain.Validator": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "avatarUrl": {
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "identity": {
+                    "type": "string"
+                },
+                "jailed": {
+                    "type": "boolean"
+                },
+                "jailedUntil": {
+                    "type": "string"
+                },
+                "missedBlocks": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "signedBlocksWindow": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tokens": {
+                    "type": "string"
+                },
+                "tombstoned": {
+                    "type": "boolean"
+                },
+                "uptime": {
+                    "description": "Uptime is a percent of signed blocks in the signed blocks window, empty if signing info is unknown.",
+                    "type": "string"
+                },
+                "votingPowerPercent": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "chain.ValidatorAPR": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "apr": {
+                    "description": "APR is a real apr of delegators net of validator commission, empty if it can't be calculated.",
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "chain.ValidatorDetails": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "avatarUrl": {
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "string"
+                },
+                "commissionUpdateTime": {
+                    "type": "string"
+                },
+                "delegation": {
+                    "description": "Delegation is an amount delegated by the delegator from request.",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "identity": {
+                    "type": "string"
+                },
+                "jailed": {
+                    "type": "boolean"
+                },
+                "jailedUntil": {
+                    "type": "string"
+                },
+                "maxChangeRate": {
+                    "type": "string"
+                },
+                "maxCommission": {
+                    "type": "string"
+                },
+                "minSelfDelegation": {
+                    "type": "string"
+                },
+                "missedBlocks": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "selfDelegation": {
+                    "type": "string"
+                },
+                "signedBlocksWindow": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tokens": {
+                    "type": "string"
+                },
+                "tombstoned": {
+                    "type": "boolean"
+                },
+                "unbondingHeight": {
+                    "type": "integer"
+                },
+                "unbondingTime": {
+                    "type": "string"
+                },
+                "uptime": {
+                    "description": "Uptime is a percent of signed blocks in the signed blocks window, empty if signing info is unknown.",
+                    "type": "string"
+                },
+                "votingPowerPercent": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "delivery.Delivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "message": {
+                    "$ref": "#/definitions/notify.Message"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/delivery.Status"
+                },
+                "target": {
+                    "$ref": "#/definitions/notify.Target"
+                },
+                "txHash": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "delivery.Status": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "retrying",
+                "delivered",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusRetrying",
+                "StatusDelivered",
+                "StatusFailed"
+            ]
+        },
+        "multisig.AccountResponse": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pubKeys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.PubKey"
+                    }
+                },
+                "threshold": {
                     "type": "integer"
                 }
             }
         },
-        "account.KeyResponse": {
+        "multisig.CombineTxInput": {
             "type": "object",
             "properties": {
-                "addresses": {
+                "broadcast": {
+                    "type": "boolean"
+                },
+                "chainId": {
+                    "type": "string"
+                },
+                "pubKeys": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/account.PubKey"
                     }
                 },
-                "key": {
+                "signatures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/multisig.Signature"
+                    }
+                },
+                "threshold": {
+                    "type": "integer"
+                },
+                "tx": {
                     "type": "string"
                 }
             }
         },
-        "account.RestoreAccountInput": {
+        "multisig.CombineTxResponse": {
+            "type": "object",
+            "properties": {
+                "txBytes": {
+                    "type": "string"
+                },
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "multisig.CreateAccountInput": {
             "type": "object",
             "properties": {
                 "chainPrefixes": {
@@ -498,98 +2097,285 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
-                "key": {
-                    "type": "string"
+                "pubKeys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.PubKey"
+                    }
+                },
+                "threshold": {
+                    "type": "integer"
                 }
             }
         },
-        "chain.PagedValidatorsResponse": {
+        "multisig.CreateTxInput": {
             "type": "object",
             "properties": {
-                "data": {
+                "amount": {
+                    "type": "string"
+                },
+                "chainId": {
+                    "type": "string"
+                },
+                "gasAdjusted": {
+                    "type": "string"
+                },
+                "gasPrice": {
+                    "type": "string"
+                },
+                "memo": {
+                    "type": "string"
+                },
+                "pubKeys": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/chain.Validator"
+                        "$ref": "#/definitions/account.PubKey"
                     }
                 },
-                "limit": {
+                "threshold": {
                     "type": "integer"
                 },
-                "offset": {
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "multisig.CreateTxResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "tx": {
+                    "type": "string"
+                }
+            }
+        },
+        "multisig.SignTxInput": {
+            "type": "object",
+            "properties": {
+                "chainId": {
+                    "type": "string"
+                },
+                "privateKey": {
+                    "type": "string"
+                },
+                "pubKeys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.PubKey"
+                    }
+                },
+                "threshold": {
                     "type": "integer"
+                },
+                "tx": {
+                    "type": "string"
                 }
             }
         },
-        "chain.ShortResponse": {
+        "multisig.Signature": {
             "type": "object",
             "properties": {
-                "base": {
+                "pubKey": {
+                    "$ref": "#/definitions/account.PubKey"
+                },
+                "signature": {
+                    "type": "string"
+                }
+            }
+        },
+        "notify.Channel": {
+            "type": "string",
+            "enum": [
+                "fcm",
+                "webhook",
+                "apns"
+            ],
+            "x-enum-varnames": [
+                "ChannelFCM",
+                "ChannelWebhook",
+                "ChannelAPNs"
+            ]
+        },
+        "notify.Message": {
+            "type": "object",
+            "properties": {
+                "body": {
                     "type": "string"
                 },
-                "bech32Prefix": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "notify.Target": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "$ref": "#/definitions/notify.Channel"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret signs webhook deliveries, it is generated at registration and returned to the registrant only.",
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "restake.GrantInput": {
+            "type": "object",
+            "properties": {
+                "address": {
                     "type": "string"
                 },
                 "chainId": {
                     "type": "string"
                 },
-                "chainName": {
+                "expiration": {
+                    "description": "Expiration of grants, one year from now by default.",
                     "type": "string"
                 },
-                "description": {
+                "gasAdjusted": {
                     "type": "string"
                 },
-                "display": {
+                "gasPrice": {
                     "type": "string"
                 },
-                "keyAlgos": {
+                "key": {
+                    "type": "string"
+                },
+                "minReward": {
+                    "description": "MinReward is a minimal total reward in display denom, smaller rewards are not compounded.",
+                    "type": "string"
+                },
+                "validators": {
+                    "description": "Validators are operator addresses, which rewards are compounded.",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                }
+            }
+        },
+        "restake.GrantResponse": {
+            "type": "object",
+            "properties": {
+                "expiration": {
+                    "type": "string"
                 },
-                "logoPngUrl": {
+                "grantee": {
                     "type": "string"
                 },
-                "logoSvgUrl": {
+                "status": {
+                    "$ref": "#/definitions/restake.GrantStatus"
+                },
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "restake.GrantStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "active",
+                "revoking"
+            ],
+            "x-enum-varnames": [
+                "GrantStatusPending",
+                "GrantStatusActive",
+                "GrantStatusRevoking"
+            ]
+        },
+        "restake.RevokeInput": {
+            "type": "object",
+            "properties": {
+                "address": {
                     "type": "string"
                 },
-                "prettyName": {
+                "chainId": {
                     "type": "string"
                 },
-                "slip44": {
-                    "type": "integer"
+                "gasAdjusted": {
+                    "type": "string"
                 },
-                "symbol": {
+                "gasPrice": {
+                    "type": "string"
+                },
+                "key": {
                     "type": "string"
                 }
             }
         },
-        "chain.Validator": {
+        "restake.RevokeResponse": {
             "type": "object",
             "properties": {
-                "address": {
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "restake.Run": {
+            "type": "object",
+            "properties": {
+                "chainId": {
                     "type": "string"
                 },
-                "commission": {
+                "createdAt": {
                     "type": "string"
                 },
-                "description": {
+                "delegator": {
                     "type": "string"
                 },
-                "identity": {
+                "error": {
                     "type": "string"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "tokens": {
+                "reward": {
                     "type": "string"
                 },
-                "website": {
+                "status": {
+                    "$ref": "#/definitions/restake.RunStatus"
+                },
+                "txHash": {
                     "type": "string"
                 }
             }
         },
+        "restake.RunStatus": {
+            "type": "string",
+            "enum": [
+                "executed",
+                "skipped",
+                "revoked",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "RunStatusExecuted",
+                "RunStatusSkipped",
+                "RunStatusRevoked",
+                "RunStatusFailed"
+            ]
+        },
         "transaction.SendInput": {
             "type": "object",
             "properties": {
@@ -643,9 +2429,21 @@ const docTemplate = `{
                 "key": {
                     "type": "string"
                 },
+                "locale": {
+                    "description": "Locale of notification texts for firebase token, e.g. \"en\" or \"ru\".",
+                    "type": "string"
+                },
                 "memo": {
                     "type": "string"
                 },
+                "notification": {
+                    "description": "Notification is a channel for tx result, firebase token is used when it is not set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/notify.Target"
+                        }
+                    ]
+                },
                 "to": {
                     "type": "string"
                 }
@@ -665,7 +2463,12 @@ const docTemplate = `{
                 "txHash": {
                     "type": "string"
                 },
+                "webhookSecret": {
+                    "description": "WebhookSecret is the secret of webhook notification signatures, it is generated for every transaction.",
+                    "type": "string"
+                },
                 "withEvents": {
+                    "description": "WithEvents is false, when tx is broadcast, but its tracking is not saved, so result could be lost on restart.",
                     "type": "boolean"
                 }
             }
@@ -679,6 +2482,9 @@ const docTemplate = `{
                 "chainId": {
                     "type": "string"
                 },
+                "currency": {
+                    "type": "string"
+                },
                 "from": {
                     "type": "string"
                 },
@@ -699,14 +2505,74 @@ const docTemplate = `{
                 "averageGasPrice": {
                     "type": "string"
                 },
+                "averageGasPriceFiat": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
                 "gasAdjusted": {
                     "type": "string"
                 },
                 "highGasPrice": {
                     "type": "string"
                 },
+                "highGasPriceFiat": {
+                    "type": "string"
+                },
                 "lowGasPrice": {
                     "type": "string"
+                },
+                "lowGasPriceFiat": {
+                    "type": "string"
+                }
+            }
+        },
+        "transaction.TxStage": {
+            "type": "string",
+            "enum": [
+                "broadcast",
+                "mempool",
+                "included",
+                "success",
+                "failure",
+                "timeout"
+            ],
+            "x-enum-varnames": [
+                "TxStageBroadcast",
+                "TxStageMempool",
+                "TxStageIncluded",
+                "TxStageSuccess",
+                "TxStageFailure",
+                "TxStageTimeout"
+            ]
+        },
+        "transaction.TxStatusEvent": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "gasUsed": {
+                    "type": "integer"
+                },
+                "gasWanted": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "log": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "stage": {
+                    "$ref": "#/definitions/transaction.TxStage"
+                },
+                "txHash": {
+                    "type": "string"
                 }
             }
         },
@@ -721,6 +2587,80 @@ const docTemplate = `{
                 },
                 "result": {}
             }
+        },
+        "watch.Alert": {
+            "type": "string",
+            "enum": [
+                "transfers",
+                "validators",
+                "governance"
+            ],
+            "x-enum-varnames": [
+                "AlertTransfers",
+                "AlertValidators",
+                "AlertGovernance"
+            ]
+        },
+        "watch.CreateInput": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "alerts": {
+                    "description": "Alerts are kinds of notifications: transfers, validators, governance; all of them when it is empty.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/watch.Alert"
+                    }
+                },
+                "chainId": {
+                    "type": "string"
+                },
+                "notification": {
+                    "$ref": "#/definitions/notify.Target"
+                }
+            }
+        },
+        "watch.RemoveInput": {
+            "type": "object",
+            "properties": {
+                "notification": {
+                    "description": "Notification is the target, which has created the watch, only it can remove the watch.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/notify.Target"
+                        }
+                    ]
+                }
+            }
+        },
+        "watch.Watch": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "alerts": {
+                    "description": "Alerts are kinds of notifications, all of them are sent when it is empty.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/watch.Alert"
+                    }
+                },
+                "chainId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "target": {
+                    "$ref": "#/definitions/notify.Target"
+                }
+            }
         }
     }
 }`
@@ -7,19 +7,49 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	_ "github.com/Mobile-Web3/backend/docs/api"
+	"github.com/Mobile-Web3/backend/internal/apns"
+	"github.com/Mobile-Web3/backend/internal/coingecko"
+	"github.com/Mobile-Web3/backend/internal/db/file"
 	"github.com/Mobile-Web3/backend/internal/db/memory"
 	"github.com/Mobile-Web3/backend/internal/domain/account"
+	"github.com/Mobile-Web3/backend/internal/domain/alert"
+	"github.com/Mobile-Web3/backend/internal/domain/avatar"
 	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/internal/domain/delivery"
+	"github.com/Mobile-Web3/backend/internal/domain/multisig"
+	"github.com/Mobile-Web3/backend/internal/domain/price"
+	"github.com/Mobile-Web3/backend/internal/domain/restake"
 	"github.com/Mobile-Web3/backend/internal/domain/transaction"
+	"github.com/Mobile-Web3/backend/internal/domain/watch"
 	"github.com/Mobile-Web3/backend/internal/firebase"
 	"github.com/Mobile-Web3/backend/internal/github"
 	httphandler "github.com/Mobile-Web3/backend/internal/handler/http"
+	"github.com/Mobile-Web3/backend/internal/keybase"
+	"github.com/Mobile-Web3/backend/internal/notify"
 	"github.com/Mobile-Web3/backend/internal/server/http"
+	"github.com/Mobile-Web3/backend/internal/webhook"
 	"github.com/Mobile-Web3/backend/pkg/cosmos"
 	"github.com/Mobile-Web3/backend/pkg/env"
 	"github.com/Mobile-Web3/backend/pkg/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	defaultPriceCurrency      = "usd"
+	defaultPriceCacheTTL      = time.Minute * 5
+	defaultTxTrackingTimeout  = time.Minute * 5
+	defaultPendingTxFilePath  = "data/pending_transactions.json"
+	defaultWatchFilePath      = "data/watches.json"
+	defaultDeadLetterFilePath = "data/dead_letters.json"
+	defaultDeliveryFilePath   = "data/deliveries.json"
+	defaultAvatarFilePath     = "data/avatars.json"
+	defaultRestakeFilePath    = "data/restake.json"
+	defaultRestakeRunFilePath = "data/restake_runs.json"
+	defaultAlertFilePath      = "data/alerts.json"
+	defaultAvatarCacheTTL     = time.Hour * 24
 )
 
 var (
@@ -56,13 +86,80 @@ func Run() {
 
 	chainRepository := memory.NewChainLavaRepository(memory.NewChainRepository())
 	chainRegistryClient := github.NewChainRegistryClient(logger)
-	cosmosClient, err := cosmos.NewClient("direct", firebaseCloudMessaging.SendTxResult, chainRepository.GetRPCEndpoints)
+	cosmosClient, err := cosmos.NewClient(logger, "direct", chainRepository.GetRPCEndpoints, chainRepository.GetGRPCEndpoints, chainRepository.GetRESTEndpoints)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	defer cosmosClient.Stop()
+
+	// queries of chains with lava rpc endpoints are sent through lava before registry grpc and rest endpoints
+	cosmosClient.PreferRPC(chainRepository.GetLavaChains()...)
+
+	// LIGHT_CLIENT_CHAINS is a comma separated list of chainId:height:hash headers trusted by operator
+	if lightClientChains := os.Getenv("LIGHT_CLIENT_CHAINS"); lightClientChains != "" {
+		trustedHeaders, err := cosmos.ParseTrustedHeaders(lightClientChains)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		var lightDB dbm.DB = dbm.NewMemDB()
+		if lightDBPath := os.Getenv("LIGHT_CLIENT_DB_PATH"); lightDBPath != "" {
+			lightDB, err = dbm.NewDB("light", dbm.GoLevelDBBackend, lightDBPath)
+			if err != nil {
+				logger.Error(err)
+				return
+			}
+		}
+		defer lightDB.Close()
+		cosmosClient.EnableVerification(lightDB, trustedHeaders)
+	}
+
+	var avatarProvider avatar.Provider
+	if avatarStubFilePath := os.Getenv("AVATAR_STUB_FILE_PATH"); avatarStubFilePath != "" {
+		avatarProvider, err = file.NewAvatarProvider(avatarStubFilePath)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+	} else {
+		avatarURL := os.Getenv("AVATAR_API_URL")
+		if avatarURL == "" {
+			avatarURL = keybase.DefaultURL
+		}
+		avatarProvider = keybase.NewAvatarClient(avatarURL)
+	}
+
+	avatarCacheTTL := defaultAvatarCacheTTL
+	if avatarCacheTTLStr := os.Getenv("AVATAR_CACHE_TTL"); avatarCacheTTLStr != "" {
+		avatarCacheTTL, err = time.ParseDuration(avatarCacheTTLStr)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+	}
+
+	avatarFilePath := os.Getenv("AVATAR_FILE_PATH")
+	if avatarFilePath == "" {
+		avatarFilePath = defaultAvatarFilePath
+	}
+
+	avatarRepository, err := file.NewAvatarRepository(avatarFilePath)
 	if err != nil {
 		logger.Error(err)
 		return
 	}
 
-	chainService := chain.NewService(chainRegistryClient, chainRepository, cosmosClient)
+	avatars := avatar.NewService(logger, avatarProvider, avatarRepository, avatarCacheTTL)
+	if err = avatars.Start(context.Background()); err != nil {
+		logger.Error(err)
+		return
+	}
+	defer avatars.Stop()
+
+	chainService := chain.NewService(chainRegistryClient, chainRepository, memory.NewHealthRepository(), cosmosClient, avatars)
 	if err = chainService.UpdateChainInfo(context.Background()); err != nil {
 		logger.Error(err)
 		return
@@ -79,14 +176,189 @@ func Run() {
 		return
 	}
 
-	accounts := account.NewService(logger, chainRepository, cosmosClient)
-	transactions := transaction.NewService(gasAdjustment, logger, chainRepository, cosmosClient)
+	var priceProvider price.Provider
+	if priceFilePath := os.Getenv("PRICE_FILE_PATH"); priceFilePath != "" {
+		priceProvider, err = file.NewPriceProvider(priceFilePath)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+	} else {
+		priceURL := os.Getenv("PRICE_API_URL")
+		if priceURL == "" {
+			priceURL = coingecko.DefaultURL
+		}
+		priceProvider = coingecko.NewPriceClient(priceURL, os.Getenv("PRICE_API_KEY"), logger)
+	}
+
+	priceCurrency := os.Getenv("PRICE_CURRENCY")
+	if priceCurrency == "" {
+		priceCurrency = defaultPriceCurrency
+	}
+
+	priceCacheTTL := defaultPriceCacheTTL
+	if priceCacheTTLStr := os.Getenv("PRICE_CACHE_TTL"); priceCacheTTLStr != "" {
+		priceCacheTTL, err = time.ParseDuration(priceCacheTTLStr)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+	}
+
+	txTrackingTimeout := defaultTxTrackingTimeout
+	if txTrackingTimeoutStr := os.Getenv("TX_TRACKING_TIMEOUT"); txTrackingTimeoutStr != "" {
+		txTrackingTimeout, err = time.ParseDuration(txTrackingTimeoutStr)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+	}
+
+	pendingTxFilePath := os.Getenv("PENDING_TX_FILE_PATH")
+	if pendingTxFilePath == "" {
+		pendingTxFilePath = defaultPendingTxFilePath
+	}
+
+	pendingTxRepository, err := file.NewPendingTxRepository(pendingTxFilePath)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	notifier := notify.NewRouter()
+	notifier.Register(notify.ChannelFCM, firebaseCloudMessaging)
+	notifier.Register(notify.ChannelAPNs, apns.NewStubClient(logger))
+	notifier.Register(notify.ChannelWebhook, webhook.NewClient(logger))
+
+	deadLetterFilePath := os.Getenv("DEAD_LETTER_FILE_PATH")
+	if deadLetterFilePath == "" {
+		deadLetterFilePath = defaultDeadLetterFilePath
+	}
+
+	deadLetterRepository, err := file.NewDeadLetterRepository(deadLetterFilePath)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	deliveryFilePath := os.Getenv("DELIVERY_FILE_PATH")
+	if deliveryFilePath == "" {
+		deliveryFilePath = defaultDeliveryFilePath
+	}
+
+	deliveryRepository, err := file.NewDeliveryRepository(deliveryFilePath)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	defer deliveryRepository.Close()
+
+	deliveries := delivery.NewService(logger, notifier, deliveryRepository, deadLetterRepository)
+
+	prices := price.NewService(priceProvider, priceCacheTTL, priceCurrency)
+	accounts := account.NewService(logger, chainRepository, cosmosClient, prices)
+	transactions := transaction.NewService(gasAdjustment, txTrackingTimeout, logger, chainRepository, cosmosClient, prices,
+		pendingTxRepository, deliveries)
+	if err = transactions.ResumePendingTransactions(context.Background()); err != nil {
+		logger.Error(err)
+		return
+	}
+
+	watchFilePath := os.Getenv("WATCH_FILE_PATH")
+	if watchFilePath == "" {
+		watchFilePath = defaultWatchFilePath
+	}
+
+	watchRepository, err := file.NewWatchRepository(watchFilePath)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	watches := watch.NewService(logger, chainRepository, watchRepository, cosmosClient, deliveries)
+	deliveries.OnInvalidTarget(watches.RemoveByTarget)
+	// queued deliveries are sent after invalid target handlers are registered
+	deliveries.Start()
+	defer deliveries.Stop()
+
+	if err = watches.ResumeWatches(context.Background()); err != nil {
+		logger.Error(err)
+		return
+	}
+
+	alertFilePath := os.Getenv("ALERT_FILE_PATH")
+	if alertFilePath == "" {
+		alertFilePath = defaultAlertFilePath
+	}
+
+	alertRepository, err := file.NewAlertRepository(alertFilePath)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	alerts := alert.NewService(logger, chainRepository, alertRepository, cosmosClient, watches, deliveries)
+
+	multisigs := multisig.NewService(logger, chainRepository, cosmosClient)
+
+	// restake is enabled by the key of grantee, which executes compounding of delegators rewards
+	var restakes *restake.Service
+	if restakeKey := os.Getenv("RESTAKE_KEY"); restakeKey != "" {
+		if _, err = cosmosClient.CreateAccountFromHexKey(restakeKey); err != nil {
+			logger.Error(err)
+			return
+		}
+
+		restakeFilePath := os.Getenv("RESTAKE_FILE_PATH")
+		if restakeFilePath == "" {
+			restakeFilePath = defaultRestakeFilePath
+		}
+
+		restakeRepository, err := file.NewRestakeRepository(restakeFilePath)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		restakeRunFilePath := os.Getenv("RESTAKE_RUN_FILE_PATH")
+		if restakeRunFilePath == "" {
+			restakeRunFilePath = defaultRestakeRunFilePath
+		}
+
+		restakeRunRepository, err := file.NewRestakeRunRepository(restakeRunFilePath)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		// RESTAKE_MIN_REWARDS is a comma separated list of chainId:amount minimal rewards in display denom
+		restakeMinRewards := make(map[string]string)
+		if restakeMinRewardsStr := os.Getenv("RESTAKE_MIN_REWARDS"); restakeMinRewardsStr != "" {
+			restakeMinRewards, err = restake.ParseMinRewards(restakeMinRewardsStr)
+			if err != nil {
+				logger.Error(err)
+				return
+			}
+		}
+
+		restakes = restake.NewService(gasAdjustment, restakeMinRewards, restakeKey, logger, chainRepository, restakeRepository, restakeRunRepository, cosmosClient)
+		if err = restakes.ResumePendingGrants(context.Background()); err != nil {
+			logger.Error(err)
+			return
+		}
+	}
+
 	handler := httphandler.NewHandler(&httphandler.Dependencies{
 		Logger:             logger,
 		Repository:         chainRepository,
 		ChainService:       chainService,
 		AccountService:     accounts,
 		TransactionService: transactions,
+		MultisigService:    multisigs,
+		WatchService:       watches,
+		DeliveryService:    deliveries,
+		RestakeService:     restakes,
+		AdminToken:         os.Getenv("ADMIN_TOKEN"),
 	})
 
 	port := os.Getenv("PORT")
@@ -95,7 +367,7 @@ func Run() {
 		return
 	}
 
-	worker := NewWorker(logger, chainService)
+	worker := NewWorker(logger, chainService, alerts, restakes)
 	if err = worker.Start(); err != nil {
 		logger.Error(err)
 		return
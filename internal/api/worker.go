@@ -2,24 +2,39 @@ package api
 
 import (
 	"context"
+	"time"
 
+	"github.com/Mobile-Web3/backend/internal/domain/alert"
 	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/internal/domain/restake"
 	"github.com/Mobile-Web3/backend/pkg/log"
 	"github.com/robfig/cron/v3"
 )
 
+const (
+	endpointsMonitorTimeout  = time.Minute
+	validatorsMonitorTimeout = time.Minute * 2
+	proposalsMonitorTimeout  = time.Minute * 2
+	restakeTimeout           = time.Minute * 10
+)
+
 type Worker struct {
 	logger       log.Logger
 	chainService *chain.Service
-	scheduler    *cron.Cron
-	jobID        cron.EntryID
+	alertService *alert.Service
+	// restakeService is nil, if restake is disabled.
+	restakeService *restake.Service
+	scheduler      *cron.Cron
+	jobIDs         []cron.EntryID
 }
 
-func NewWorker(logger log.Logger, chainService *chain.Service) *Worker {
+func NewWorker(logger log.Logger, chainService *chain.Service, alertService *alert.Service, restakeService *restake.Service) *Worker {
 	worker := &Worker{
-		logger:       logger,
-		scheduler:    cron.New(),
-		chainService: chainService,
+		logger:         logger,
+		scheduler:      cron.New(),
+		chainService:   chainService,
+		alertService:   alertService,
+		restakeService: restakeService,
 	}
 
 	return worker
@@ -33,13 +48,76 @@ func (w *Worker) Start() error {
 	if err != nil {
 		return err
 	}
+	w.jobIDs = append(w.jobIDs, jobID)
+
+	jobID, err = w.scheduler.AddFunc("@every 1m", w.monitorEndpoints)
+	if err != nil {
+		return err
+	}
+	w.jobIDs = append(w.jobIDs, jobID)
+
+	jobID, err = w.scheduler.AddFunc("@every 5m", w.monitorValidators)
+	if err != nil {
+		return err
+	}
+	w.jobIDs = append(w.jobIDs, jobID)
+
+	jobID, err = w.scheduler.AddFunc("@every 10m", w.monitorProposals)
+	if err != nil {
+		return err
+	}
+	w.jobIDs = append(w.jobIDs, jobID)
+
+	if w.restakeService != nil {
+		jobID, err = w.scheduler.AddFunc("0 */6 * * *", w.restake)
+		if err != nil {
+			return err
+		}
+		w.jobIDs = append(w.jobIDs, jobID)
+	}
 
-	w.jobID = jobID
 	w.scheduler.Start()
+	go w.monitorEndpoints()
+	go w.monitorValidators()
+	go w.monitorProposals()
 	return nil
 }
 
+func (w *Worker) monitorEndpoints() {
+	ctx, cancel := context.WithTimeout(context.Background(), endpointsMonitorTimeout)
+	defer cancel()
+	if err := w.chainService.MonitorEndpoints(ctx); err != nil {
+		w.logger.Error(err)
+	}
+}
+
+func (w *Worker) monitorValidators() {
+	ctx, cancel := context.WithTimeout(context.Background(), validatorsMonitorTimeout)
+	defer cancel()
+	if err := w.alertService.MonitorValidators(ctx); err != nil {
+		w.logger.Error(err)
+	}
+}
+
+func (w *Worker) monitorProposals() {
+	ctx, cancel := context.WithTimeout(context.Background(), proposalsMonitorTimeout)
+	defer cancel()
+	if err := w.alertService.MonitorProposals(ctx); err != nil {
+		w.logger.Error(err)
+	}
+}
+
+func (w *Worker) restake() {
+	ctx, cancel := context.WithTimeout(context.Background(), restakeTimeout)
+	defer cancel()
+	if err := w.restakeService.Restake(ctx); err != nil {
+		w.logger.Error(err)
+	}
+}
+
 func (w *Worker) Stop() {
-	w.scheduler.Remove(w.jobID)
+	for _, jobID := range w.jobIDs {
+		w.scheduler.Remove(jobID)
+	}
 	w.scheduler.Stop()
 }
@@ -0,0 +1,35 @@
+package apns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mobile-Web3/backend/internal/notify"
+	"github.com/Mobile-Web3/backend/pkg/log"
+)
+
+// StubClient accepts APNs notifications and only logs them until APNs credentials are configured.
+type StubClient struct {
+	logger log.Logger
+}
+
+func NewStubClient(logger log.Logger) *StubClient {
+	return &StubClient{
+		logger: logger,
+	}
+}
+
+func (c *StubClient) Notify(ctx context.Context, target notify.Target, message notify.Message) error {
+	c.logger.Info(fmt.Sprintf("apns stub; token: %s; type: %s; data: %v", maskToken(target.Token), message.Type, message.Data))
+	return nil
+}
+
+// maskToken keeps only the last characters of device token, so logs can't be used to send notifications.
+func maskToken(token string) string {
+	const visible = 6
+	if len(token) <= visible {
+		return "***"
+	}
+
+	return "***" + token[len(token)-visible:]
+}
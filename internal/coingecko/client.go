@@ -0,0 +1,77 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Mobile-Web3/backend/pkg/log"
+)
+
+const DefaultURL = "https://api.coingecko.com/api/v3"
+
+// PriceClient requests prices from CoinGecko compatible simple price api.
+type PriceClient struct {
+	logger  log.Logger
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewPriceClient(baseURL string, apiKey string, logger log.Logger) *PriceClient {
+	return &PriceClient{
+		logger:  logger,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		client: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+}
+
+func (c *PriceClient) GetPrices(ctx context.Context, ids []string, currency string) (map[string]float64, error) {
+	query := url.Values{}
+	query.Set("ids", strings.Join(ids, ","))
+	query.Set("vs_currencies", currency)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/simple/price?%s", c.baseURL, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.apiKey != "" {
+		request.Header.Set("x-cg-pro-api-key", c.apiKey)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("price api respond with status code: %d", response.StatusCode)
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	var prices map[string]map[string]float64
+	if err = json.NewDecoder(response.Body).Decode(&prices); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	result := make(map[string]float64)
+	for id, values := range prices {
+		if value, ok := values[currency]; ok {
+			result[id] = value
+		}
+	}
+
+	return result, nil
+}
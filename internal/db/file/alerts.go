@@ -0,0 +1,76 @@
+package file
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Mobile-Web3/backend/internal/domain/alert"
+)
+
+type alertState struct {
+	Validators map[string]map[string]alert.ValidatorState `json:"validators"`
+	Proposals  map[string]alert.ProposalsState            `json:"proposals"`
+}
+
+// AlertRepository keeps snapshots of the last alert checks in json file,
+// so changes happened during restart are notified and proposals are not notified twice.
+type AlertRepository struct {
+	path  string
+	mutex sync.RWMutex
+	state alertState
+}
+
+func NewAlertRepository(path string) (*AlertRepository, error) {
+	repository := &AlertRepository{
+		path: path,
+		state: alertState{
+			Validators: make(map[string]map[string]alert.ValidatorState),
+			Proposals:  make(map[string]alert.ProposalsState),
+		},
+	}
+
+	if err := loadJSON(path, &repository.state); err != nil {
+		return nil, err
+	}
+
+	if repository.state.Validators == nil {
+		repository.state.Validators = make(map[string]map[string]alert.ValidatorState)
+	}
+	if repository.state.Proposals == nil {
+		repository.state.Proposals = make(map[string]alert.ProposalsState)
+	}
+
+	return repository, nil
+}
+
+func (r *AlertRepository) GetValidators(ctx context.Context, chainID string) (map[string]alert.ValidatorState, bool, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	validators, ok := r.state.Validators[chainID]
+	return validators, ok, nil
+}
+
+func (r *AlertRepository) SaveValidators(ctx context.Context, chainID string, validators map[string]alert.ValidatorState) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.state.Validators[chainID] = validators
+	return saveJSON(r.path, r.state)
+}
+
+func (r *AlertRepository) GetProposals(ctx context.Context, chainID string) (alert.ProposalsState, bool, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	state, ok := r.state.Proposals[chainID]
+	return state, ok, nil
+}
+
+func (r *AlertRepository) SaveProposals(ctx context.Context, chainID string, state alert.ProposalsState) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.state.Proposals[chainID] = state
+	return saveJSON(r.path, r.state)
+}
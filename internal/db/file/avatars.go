@@ -0,0 +1,81 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Mobile-Web3/backend/internal/domain/avatar"
+)
+
+// AvatarRepository keeps resolved avatars in json file keyed by identity.
+type AvatarRepository struct {
+	path    string
+	mutex   sync.Mutex
+	avatars map[string]avatar.Avatar
+}
+
+func NewAvatarRepository(path string) (*AvatarRepository, error) {
+	repository := &AvatarRepository{
+		path:    path,
+		avatars: make(map[string]avatar.Avatar),
+	}
+
+	if err := loadJSON(path, &repository.avatars); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func (r *AvatarRepository) GetAll(ctx context.Context) ([]avatar.Avatar, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := make([]avatar.Avatar, 0, len(r.avatars))
+	for _, item := range r.avatars {
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (r *AvatarRepository) Save(ctx context.Context, item avatar.Avatar) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.avatars[item.Identity] = item
+	return saveJSON(r.path, r.avatars)
+}
+
+// AvatarProvider returns avatars from static json file instead of keybase, so it is used locally and in tests:
+// {"D75509198CE782A6": "https://example.com/avatar.jpg"}.
+type AvatarProvider struct {
+	avatars map[string]string
+}
+
+func NewAvatarProvider(path string) (*AvatarProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var avatars map[string]string
+	if err = json.Unmarshal(data, &avatars); err != nil {
+		return nil, err
+	}
+
+	provider := &AvatarProvider{
+		avatars: make(map[string]string, len(avatars)),
+	}
+	for identity, url := range avatars {
+		provider.avatars[strings.ToUpper(identity)] = url
+	}
+
+	return provider, nil
+}
+
+func (p *AvatarProvider) GetAvatarURL(ctx context.Context, identity string) (string, error) {
+	return p.avatars[strings.ToUpper(identity)], nil
+}
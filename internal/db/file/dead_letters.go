@@ -0,0 +1,44 @@
+package file
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Mobile-Web3/backend/internal/domain/delivery"
+)
+
+// DeadLetterRepository keeps permanently failed deliveries in json file for manual inspection.
+type DeadLetterRepository struct {
+	path       string
+	mutex      sync.Mutex
+	deliveries []delivery.Delivery
+}
+
+func NewDeadLetterRepository(path string) (*DeadLetterRepository, error) {
+	repository := &DeadLetterRepository{
+		path: path,
+	}
+
+	if err := loadJSON(path, &repository.deliveries); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func (r *DeadLetterRepository) Add(ctx context.Context, item delivery.Delivery) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.deliveries = append(r.deliveries, item)
+	return saveJSON(r.path, r.deliveries)
+}
+
+func (r *DeadLetterRepository) GetAll(ctx context.Context) ([]delivery.Delivery, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := make([]delivery.Delivery, len(r.deliveries))
+	copy(result, r.deliveries)
+	return result, nil
+}
@@ -0,0 +1,243 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mobile-Web3/backend/internal/domain/delivery"
+)
+
+const (
+	// maxDeliveries is a count of the latest delivered and failed deliveries kept in the log.
+	maxDeliveries = 10000
+	// maxJournalRecord limits a size of a single delivery record in the journal.
+	maxJournalRecord = 1024 * 1024
+)
+
+// DeliveryRepository keeps queued deliveries and the log of the latest finished ones.
+// Every saved delivery is appended to json lines journal, so save doesn't rewrite the whole file
+// and queued deliveries survive restart. Journal is compacted, when it mostly consists of outdated records.
+type DeliveryRepository struct {
+	path    string
+	mutex   sync.RWMutex
+	journal *os.File
+	// records is a count of records in the journal including outdated ones.
+	records int
+	// queue are pending and retrying deliveries by id, they are never evicted.
+	queue map[string]delivery.Delivery
+	// finished are the latest delivered and failed deliveries in order of finishing.
+	finished []delivery.Delivery
+}
+
+func NewDeliveryRepository(path string) (*DeliveryRepository, error) {
+	repository := &DeliveryRepository{
+		path:  path,
+		queue: make(map[string]delivery.Delivery),
+	}
+
+	if err := repository.load(); err != nil {
+		return nil, err
+	}
+
+	if err := repository.compact(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func isQueued(item delivery.Delivery) bool {
+	return item.Status == delivery.StatusPending || item.Status == delivery.StatusRetrying
+}
+
+// load replays the journal, record written partially due to crash is skipped.
+func (r *DeliveryRepository) load() error {
+	file, err := os.Open(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJournalRecord)
+	for scanner.Scan() {
+		var item delivery.Delivery
+		if err = json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			continue
+		}
+		r.apply(item)
+	}
+
+	return scanner.Err()
+}
+
+// apply puts the delivery to the queue or moves it to the finished ones.
+func (r *DeliveryRepository) apply(item delivery.Delivery) {
+	if isQueued(item) {
+		r.queue[item.ID] = item
+		return
+	}
+
+	delete(r.queue, item.ID)
+	for index := range r.finished {
+		if r.finished[index].ID == item.ID {
+			r.finished[index] = item
+			return
+		}
+	}
+
+	r.finished = append(r.finished, item)
+	if len(r.finished) > maxDeliveries {
+		r.finished = append([]delivery.Delivery(nil), r.finished[len(r.finished)-maxDeliveries:]...)
+	}
+}
+
+// compact rewrites the journal atomically with the actual deliveries only and reopens it for appending.
+func (r *DeliveryRepository) compact() error {
+	if r.journal != nil {
+		_ = r.journal.Close()
+		r.journal = nil
+	}
+
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	items := make([]delivery.Delivery, 0, len(r.finished)+len(r.queue))
+	items = append(items, r.finished...)
+	items = append(items, r.sortedQueue()...)
+
+	var builder strings.Builder
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		builder.Write(data)
+		builder.WriteByte('\n')
+	}
+
+	tmpPath := r.path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(builder.String()), 0644); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		return err
+	}
+
+	journal, err := os.OpenFile(r.path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	r.journal = journal
+	r.records = len(items)
+	return nil
+}
+
+// sortedQueue returns queued deliveries in order of creation.
+func (r *DeliveryRepository) sortedQueue() []delivery.Delivery {
+	result := make([]delivery.Delivery, 0, len(r.queue))
+	for _, item := range r.queue {
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+func (r *DeliveryRepository) Save(ctx context.Context, item delivery.Delivery) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	if r.journal == nil {
+		if err = r.compact(); err != nil {
+			return err
+		}
+	}
+
+	if _, err = r.journal.Write(append(data, '\n')); err != nil {
+		// journal could end with partial record, so it is rewritten on the next save
+		_ = r.journal.Close()
+		r.journal = nil
+		return err
+	}
+
+	r.apply(item)
+	r.records++
+
+	if r.records > 2*(len(r.queue)+len(r.finished))+maxDeliveries {
+		return r.compact()
+	}
+
+	return nil
+}
+
+func (r *DeliveryRepository) GetByTxHash(ctx context.Context, txHash string) ([]delivery.Delivery, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]delivery.Delivery, 0)
+	for _, item := range r.finished {
+		if strings.EqualFold(item.TxHash, txHash) {
+			result = append(result, item)
+		}
+	}
+
+	for _, item := range r.sortedQueue() {
+		if strings.EqualFold(item.TxHash, txHash) {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *DeliveryRepository) GetDue(ctx context.Context, now time.Time) ([]delivery.Delivery, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]delivery.Delivery, 0)
+	for _, item := range r.sortedQueue() {
+		if item.NextAttemptAt == nil || !item.NextAttemptAt.After(now) {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+// Close closes the journal, deliveries must not be saved after it.
+func (r *DeliveryRepository) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.journal == nil {
+		return nil
+	}
+
+	err := r.journal.Close()
+	r.journal = nil
+	return err
+}
@@ -0,0 +1,58 @@
+package file
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Mobile-Web3/backend/internal/domain/transaction"
+)
+
+// PendingTxRepository keeps pending transactions in json file, so they survive restarts.
+type PendingTxRepository struct {
+	path  string
+	mutex sync.Mutex
+	txs   []transaction.PendingTx
+}
+
+func NewPendingTxRepository(path string) (*PendingTxRepository, error) {
+	repository := &PendingTxRepository{
+		path: path,
+	}
+
+	if err := loadJSON(path, &repository.txs); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func (r *PendingTxRepository) GetAll(ctx context.Context) ([]transaction.PendingTx, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := make([]transaction.PendingTx, len(r.txs))
+	copy(result, r.txs)
+	return result, nil
+}
+
+func (r *PendingTxRepository) Add(ctx context.Context, tx transaction.PendingTx) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.txs = append(r.txs, tx)
+	return saveJSON(r.path, r.txs)
+}
+
+func (r *PendingTxRepository) Remove(ctx context.Context, chainID string, txHash string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	txs := r.txs[:0]
+	for _, tx := range r.txs {
+		if tx.ChainID != chainID || tx.TxHash != txHash {
+			txs = append(txs, tx)
+		}
+	}
+	r.txs = txs
+	return saveJSON(r.path, r.txs)
+}
@@ -0,0 +1,40 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+)
+
+// PriceProvider returns prices from static json file in CoinGecko simple price format:
+// {"cosmos": {"usd": 10.5, "eur": 9.8}}.
+type PriceProvider struct {
+	prices map[string]map[string]float64
+}
+
+func NewPriceProvider(path string) (*PriceProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var prices map[string]map[string]float64
+	if err = json.Unmarshal(data, &prices); err != nil {
+		return nil, err
+	}
+
+	return &PriceProvider{
+		prices: prices,
+	}, nil
+}
+
+func (p *PriceProvider) GetPrices(ctx context.Context, ids []string, currency string) (map[string]float64, error) {
+	result := make(map[string]float64)
+	for _, id := range ids {
+		if value, ok := p.prices[id][currency]; ok {
+			result[id] = value
+		}
+	}
+
+	return result, nil
+}
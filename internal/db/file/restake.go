@@ -0,0 +1,111 @@
+package file
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Mobile-Web3/backend/internal/domain/restake"
+)
+
+// restakeRunsLimit is a count of the last runs, which are kept in the run log.
+const restakeRunsLimit = 10000
+
+// RestakeRepository keeps restake grants of delegators in json file.
+type RestakeRepository struct {
+	path   string
+	mutex  sync.Mutex
+	grants []restake.Grant
+}
+
+func NewRestakeRepository(path string) (*RestakeRepository, error) {
+	repository := &RestakeRepository{
+		path: path,
+	}
+
+	if err := loadJSON(path, &repository.grants); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func (r *RestakeRepository) GetAll(ctx context.Context) ([]restake.Grant, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := make([]restake.Grant, len(r.grants))
+	copy(result, r.grants)
+	return result, nil
+}
+
+func (r *RestakeRepository) Save(ctx context.Context, grant restake.Grant) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for index, item := range r.grants {
+		if item.ChainID == grant.ChainID && item.Delegator == grant.Delegator {
+			r.grants[index] = grant
+			return saveJSON(r.path, r.grants)
+		}
+	}
+
+	r.grants = append(r.grants, grant)
+	return saveJSON(r.path, r.grants)
+}
+
+func (r *RestakeRepository) Remove(ctx context.Context, chainID string, delegator string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	grants := r.grants[:0]
+	for _, item := range r.grants {
+		if item.ChainID != chainID || item.Delegator != delegator {
+			grants = append(grants, item)
+		}
+	}
+	r.grants = grants
+	return saveJSON(r.path, r.grants)
+}
+
+// RestakeRunRepository keeps the last restake runs in json file.
+type RestakeRunRepository struct {
+	path  string
+	mutex sync.Mutex
+	runs  []restake.Run
+}
+
+func NewRestakeRunRepository(path string) (*RestakeRunRepository, error) {
+	repository := &RestakeRunRepository{
+		path: path,
+	}
+
+	if err := loadJSON(path, &repository.runs); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func (r *RestakeRunRepository) Add(ctx context.Context, runs ...restake.Run) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.runs = append(r.runs, runs...)
+	if len(r.runs) > restakeRunsLimit {
+		r.runs = append([]restake.Run(nil), r.runs[len(r.runs)-restakeRunsLimit:]...)
+	}
+	return saveJSON(r.path, r.runs)
+}
+
+func (r *RestakeRunRepository) GetByDelegator(ctx context.Context, chainID string, delegator string) ([]restake.Run, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := make([]restake.Run, 0)
+	for _, item := range r.runs {
+		if item.ChainID == chainID && item.Delegator == delegator {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package file
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Mobile-Web3/backend/internal/domain/watch"
+)
+
+// WatchRepository keeps address watches in json file.
+type WatchRepository struct {
+	path    string
+	mutex   sync.Mutex
+	watches []watch.Watch
+}
+
+func NewWatchRepository(path string) (*WatchRepository, error) {
+	repository := &WatchRepository{
+		path: path,
+	}
+
+	if err := loadJSON(path, &repository.watches); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func (r *WatchRepository) GetAll(ctx context.Context) ([]watch.Watch, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := make([]watch.Watch, len(r.watches))
+	copy(result, r.watches)
+	return result, nil
+}
+
+func (r *WatchRepository) Add(ctx context.Context, item watch.Watch) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.watches = append(r.watches, item)
+	return saveJSON(r.path, r.watches)
+}
+
+func (r *WatchRepository) Remove(ctx context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	watches := r.watches[:0]
+	for _, item := range r.watches {
+		if item.ID != id {
+			watches = append(watches, item)
+		}
+	}
+	r.watches = watches
+	return saveJSON(r.path, r.watches)
+}
@@ -67,7 +67,7 @@ func (r *ChainRepository) UpdateChains(ctx context.Context, chains []chain.Chain
 	return nil
 }
 
-func (r *ChainRepository) GetRPCEndpoints(ctx context.Context, chainID string) ([]string, error) {
+func (r *ChainRepository) getEndpoints(chainID string, getApi func(api chain.Api) []chain.Rpc) ([]string, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
@@ -77,9 +77,27 @@ func (r *ChainRepository) GetRPCEndpoints(ctx context.Context, chainID string) (
 	}
 
 	var endpoints []string
-	for _, endpoint := range chainData.Api.Rpc {
+	for _, endpoint := range getApi(chainData.Api) {
 		endpoints = append(endpoints, endpoint.Address)
 	}
 
 	return endpoints, nil
 }
+
+func (r *ChainRepository) GetRPCEndpoints(ctx context.Context, chainID string) ([]string, error) {
+	return r.getEndpoints(chainID, func(api chain.Api) []chain.Rpc {
+		return api.Rpc
+	})
+}
+
+func (r *ChainRepository) GetGRPCEndpoints(ctx context.Context, chainID string) ([]string, error) {
+	return r.getEndpoints(chainID, func(api chain.Api) []chain.Rpc {
+		return api.Grpc
+	})
+}
+
+func (r *ChainRepository) GetRESTEndpoints(ctx context.Context, chainID string) ([]string, error) {
+	return r.getEndpoints(chainID, func(api chain.Api) []chain.Rpc {
+		return api.Rest
+	})
+}
@@ -24,6 +24,15 @@ func NewChainLavaRepository(repository chain.Repository) *ChainsLavaRepository {
 	}
 }
 
+// GetLavaChains returns ids of chains, which rpc endpoints are replaced by lava ones.
+func (r *ChainsLavaRepository) GetLavaChains() []string {
+	result := make([]string, 0, len(r.lavaEndpoints))
+	for chainID := range r.lavaEndpoints {
+		result = append(result, chainID)
+	}
+	return result
+}
+
 func (r *ChainsLavaRepository) GetAllChains(ctx context.Context) ([]chain.ShortResponse, error) {
 	return r.repository.GetAllChains(ctx)
 }
@@ -45,3 +54,11 @@ func (r *ChainsLavaRepository) GetRPCEndpoints(ctx context.Context, chainID stri
 
 	return r.repository.GetRPCEndpoints(ctx, chainID)
 }
+
+func (r *ChainsLavaRepository) GetGRPCEndpoints(ctx context.Context, chainID string) ([]string, error) {
+	return r.repository.GetGRPCEndpoints(ctx, chainID)
+}
+
+func (r *ChainsLavaRepository) GetRESTEndpoints(ctx context.Context, chainID string) ([]string, error) {
+	return r.repository.GetRESTEndpoints(ctx, chainID)
+}
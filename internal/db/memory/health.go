@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Mobile-Web3/backend/internal/domain/chain"
+)
+
+var ErrHealthNotChecked = errors.New("chain endpoints are not checked yet")
+
+type HealthRepository struct {
+	health map[string]chain.EndpointsHealth
+	mutex  sync.RWMutex
+}
+
+func NewHealthRepository() *HealthRepository {
+	return &HealthRepository{
+		health: make(map[string]chain.EndpointsHealth),
+		mutex:  sync.RWMutex{},
+	}
+}
+
+func (r *HealthRepository) GetEndpointsHealth(ctx context.Context, chainID string) (chain.EndpointsHealth, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	health, ok := r.health[chainID]
+	if !ok {
+		return chain.EndpointsHealth{}, ErrHealthNotChecked
+	}
+
+	return health, nil
+}
+
+func (r *HealthRepository) UpdateEndpointsHealth(ctx context.Context, health chain.EndpointsHealth) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.health[health.ChainID] = health
+	return nil
+}
@@ -0,0 +1,269 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/internal/domain/price"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distribution "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+const (
+	maxPortfolioAccounts  = 50
+	portfolioParallelism  = 8
+	portfolioChainTimeout = time.Second * 10
+)
+
+type PortfolioAccount struct {
+	ChainID string `json:"chainId"`
+	Address string `json:"address"`
+}
+
+type PortfolioInput struct {
+	Accounts []PortfolioAccount `json:"accounts"`
+	Currency string             `json:"currency"`
+}
+
+func (input PortfolioInput) Validate() error {
+	var errs []string
+	if len(input.Accounts) == 0 {
+		errs = append(errs, "at least one account is needed")
+	}
+
+	if len(input.Accounts) > maxPortfolioAccounts {
+		errs = append(errs, fmt.Sprintf("too many accounts, max %d", maxPortfolioAccounts))
+	}
+
+	for index, portfolioAccount := range input.Accounts {
+		if portfolioAccount.ChainID == "" {
+			errs = append(errs, fmt.Sprintf("invalid chainId of account %d", index))
+		}
+
+		if portfolioAccount.Address == "" {
+			errs = append(errs, fmt.Sprintf("invalid address of account %d", index))
+		}
+	}
+
+	if len(errs) > 0 {
+		return formatErrors(errs)
+	}
+
+	return nil
+}
+
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+type Delegation struct {
+	ValidatorAddress string `json:"validatorAddress"`
+	Amount           string `json:"amount"`
+	Rewards          string `json:"rewards"`
+}
+
+type ChainPortfolio struct {
+	ChainID         string `json:"chainId"`
+	Address         string `json:"address"`
+	Symbol          string `json:"symbol"`
+	TotalAmount     string `json:"totalAmount"`
+	AvailableAmount string `json:"availableAmount"`
+	StakedAmount    string `json:"stakedAmount"`
+	RewardsAmount   string `json:"rewardsAmount"`
+	// Price is omitted with fiat values, when price of the asset is unknown.
+	Price       *float64     `json:"price,omitempty"`
+	TotalFiat   string       `json:"totalFiat,omitempty"`
+	Balances    []Coin       `json:"balances"`
+	Delegations []Delegation `json:"delegations"`
+	Error       string       `json:"error,omitempty"`
+
+	// assetID is a price id of the chain asset.
+	assetID string
+}
+
+type PortfolioResponse struct {
+	Currency  string           `json:"currency"`
+	TotalFiat string           `json:"totalFiat"`
+	Chains    []ChainPortfolio `json:"chains"`
+}
+
+// GetPortfolio collects balances, delegations and rewards of every account concurrently.
+// Errors are isolated per chain, so one slow or broken chain does not fail the whole portfolio.
+// Prices of all chain assets are requested at once after balances are collected.
+func (s *Service) GetPortfolio(ctx context.Context, input PortfolioInput) (PortfolioResponse, error) {
+	currency := s.prices.Currency(input.Currency)
+	result := make([]ChainPortfolio, len(input.Accounts))
+	semaphore := make(chan struct{}, portfolioParallelism)
+	wg := &sync.WaitGroup{}
+
+	for index, portfolioAccount := range input.Accounts {
+		wg.Add(1)
+		go func(index int, portfolioAccount PortfolioAccount) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			chainCtx, cancel := context.WithTimeout(ctx, portfolioChainTimeout)
+			defer cancel()
+
+			portfolio, err := s.getChainPortfolio(chainCtx, portfolioAccount)
+			if err != nil {
+				s.logger.Error(err)
+				portfolio = ChainPortfolio{
+					ChainID: portfolioAccount.ChainID,
+					Address: portfolioAccount.Address,
+					Error:   err.Error(),
+				}
+			}
+			result[index] = portfolio
+		}(index, portfolioAccount)
+	}
+
+	wg.Wait()
+	s.setPortfolioPrices(ctx, result, currency)
+
+	var totalFiat float64
+	for _, portfolio := range result {
+		value, err := strconv.ParseFloat(portfolio.TotalFiat, 64)
+		if err == nil {
+			totalFiat += value
+		}
+	}
+
+	return PortfolioResponse{
+		Currency:  currency,
+		TotalFiat: fmt.Sprintf("%.2f", totalFiat),
+		Chains:    result,
+	}, nil
+}
+
+// setPortfolioPrices sets prices and fiat values of chains; prices are optional, so errors are only logged.
+func (s *Service) setPortfolioPrices(ctx context.Context, portfolios []ChainPortfolio, currency string) {
+	var ids []string
+	unique := make(map[string]struct{})
+	for _, portfolio := range portfolios {
+		if _, ok := unique[portfolio.assetID]; ok || portfolio.assetID == "" {
+			continue
+		}
+		unique[portfolio.assetID] = struct{}{}
+		ids = append(ids, portfolio.assetID)
+	}
+
+	if len(ids) == 0 {
+		return
+	}
+
+	prices, err := s.prices.GetPrices(ctx, ids, currency)
+	if err != nil {
+		s.logger.Error(err)
+		return
+	}
+
+	for index := range portfolios {
+		value, ok := prices[portfolios[index].assetID]
+		if !ok || portfolios[index].assetID == "" {
+			continue
+		}
+
+		portfolios[index].Price = &value
+		portfolios[index].TotalFiat = price.FiatValue(portfolios[index].TotalAmount, value)
+	}
+}
+
+func (s *Service) getChainPortfolio(ctx context.Context, input PortfolioAccount) (ChainPortfolio, error) {
+	chainInfo, err := s.chainRepository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return ChainPortfolio{}, err
+	}
+
+	denom, exponent, err := chain.GetBaseDenom(chainInfo.Asset.Base, chainInfo.Asset.Display, chainInfo.Asset.DenomUnits)
+	if err != nil {
+		err = fmt.Errorf("chain: %s; %s", chainInfo.Name, err.Error())
+		return ChainPortfolio{}, err
+	}
+
+	connection := s.cosmosClient.GetChainGrpcClient(chainInfo.ID)
+	bankResponse, err := bank.NewQueryClient(connection).AllBalances(ctx, &bank.QueryAllBalancesRequest{
+		Address: input.Address,
+	})
+	if err != nil {
+		err = fmt.Errorf("chain: %s; balances; %s", chainInfo.ID, err.Error())
+		return ChainPortfolio{}, err
+	}
+
+	stakingResponse, err := staking.NewQueryClient(connection).DelegatorDelegations(ctx, &staking.QueryDelegatorDelegationsRequest{
+		DelegatorAddr: input.Address,
+	})
+	if err != nil {
+		err = fmt.Errorf("chain: %s; delegations; %s", chainInfo.ID, err.Error())
+		return ChainPortfolio{}, err
+	}
+
+	rewardsResponse, err := distribution.NewQueryClient(connection).DelegationTotalRewards(ctx, &distribution.QueryDelegationTotalRewardsRequest{
+		DelegatorAddress: input.Address,
+	})
+	if err != nil {
+		err = fmt.Errorf("chain: %s; rewards; %s", chainInfo.ID, err.Error())
+		return ChainPortfolio{}, err
+	}
+
+	toDisplay := func(amount sdkmath.Int) string {
+		return chain.FromBaseToDisplay(amount.String(), exponent)
+	}
+
+	available := bankResponse.Balances.AmountOf(denom)
+	balances := make([]Coin, len(bankResponse.Balances))
+	for index, balance := range bankResponse.Balances {
+		amount := balance.Amount.String()
+		if balance.Denom == denom {
+			amount = toDisplay(balance.Amount)
+		}
+		balances[index] = Coin{
+			Denom:  balance.Denom,
+			Amount: amount,
+		}
+	}
+
+	validatorRewards := make(map[string]sdkmath.Int)
+	for _, reward := range rewardsResponse.Rewards {
+		validatorRewards[reward.ValidatorAddress] = reward.Reward.AmountOf(denom).TruncateInt()
+	}
+
+	staked := sdkmath.NewInt(0)
+	delegations := make([]Delegation, len(stakingResponse.DelegationResponses))
+	for index, delegation := range stakingResponse.DelegationResponses {
+		staked = staked.Add(delegation.Balance.Amount)
+		rewards, ok := validatorRewards[delegation.Delegation.ValidatorAddress]
+		if !ok {
+			rewards = sdkmath.NewInt(0)
+		}
+		delegations[index] = Delegation{
+			ValidatorAddress: delegation.Delegation.ValidatorAddress,
+			Amount:           toDisplay(delegation.Balance.Amount),
+			Rewards:          toDisplay(rewards),
+		}
+	}
+
+	rewards := rewardsResponse.Total.AmountOf(denom).TruncateInt()
+	portfolio := ChainPortfolio{
+		ChainID:         chainInfo.ID,
+		Address:         input.Address,
+		Symbol:          chainInfo.Asset.Symbol,
+		TotalAmount:     toDisplay(available.Add(staked).Add(rewards)),
+		AvailableAmount: toDisplay(available),
+		StakedAmount:    toDisplay(staked),
+		RewardsAmount:   toDisplay(rewards),
+		Balances:        balances,
+		Delegations:     delegations,
+		assetID:         price.AssetID(chainInfo.Asset),
+	}
+
+	return portfolio, nil
+}
@@ -2,12 +2,15 @@ package account
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	sdkmath "cosmossdk.io/math"
 	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/internal/domain/price"
 	"github.com/Mobile-Web3/backend/pkg/cosmos"
 	"github.com/Mobile-Web3/backend/pkg/log"
 	"github.com/cosmos/cosmos-sdk/crypto/types"
@@ -19,16 +22,29 @@ type Service struct {
 	logger          log.Logger
 	chainRepository chain.Repository
 	cosmosClient    *cosmos.Client
+	prices          *price.Service
 }
 
-func NewService(logger log.Logger, chainRepository chain.Repository, cosmosClient *cosmos.Client) *Service {
+func NewService(logger log.Logger, chainRepository chain.Repository, cosmosClient *cosmos.Client, prices *price.Service) *Service {
 	return &Service{
 		logger:          logger,
 		chainRepository: chainRepository,
 		cosmosClient:    cosmosClient,
+		prices:          prices,
 	}
 }
 
+// getPrice returns fiat price of chain asset; prices are optional, so errors are only logged.
+func (s *Service) getPrice(ctx context.Context, asset chain.Asset, currency string) (float64, bool) {
+	value, err := s.prices.GetPrice(ctx, asset, currency)
+	if err != nil {
+		s.logger.Error(err)
+		return 0, false
+	}
+
+	return value, true
+}
+
 type CreateMnemonicInput struct {
 	MnemonicSize uint8 `json:"mnemonicSize"`
 }
@@ -165,11 +181,232 @@ func (s *Service) RestoreAccount(ctx context.Context, input RestoreAccountInput)
 	}, nil
 }
 
-type BalanceInput struct {
-	ChainID string `json:"chainId"`
+type PubKey struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type SignArbitraryInput struct {
+	Key         string `json:"key"`
+	ChainPrefix string `json:"chainPrefix"`
+	Data        string `json:"data"`
+}
+
+func (input SignArbitraryInput) Validate() error {
+	var errs []string
+	if input.Key == "" {
+		errs = append(errs, "invalid key")
+	}
+
+	if input.ChainPrefix == "" {
+		errs = append(errs, "invalid chainPrefix")
+	}
+
+	if input.Data == "" {
+		errs = append(errs, "invalid data")
+	}
+
+	if len(errs) > 0 {
+		return formatErrors(errs)
+	}
+
+	return nil
+}
+
+type SignArbitraryResponse struct {
+	Signer    string `json:"signer"`
+	Data      string `json:"data"`
+	PubKey    PubKey `json:"pubKey"`
+	Signature string `json:"signature"`
+}
+
+func (s *Service) SignArbitrary(ctx context.Context, input SignArbitraryInput) (SignArbitraryResponse, error) {
+	privateKey, err := s.cosmosClient.CreateAccountFromHexKey(input.Key)
+	if err != nil {
+		return SignArbitraryResponse{}, err
+	}
+
+	signer, err := s.cosmosClient.ConvertAddressPrefix(input.ChainPrefix, privateKey.PubKey().Address())
+	if err != nil {
+		return SignArbitraryResponse{}, err
+	}
+
+	signature, err := s.cosmosClient.SignArbitrary(privateKey, signer, []byte(input.Data))
+	if err != nil {
+		s.logger.Error(err)
+		return SignArbitraryResponse{}, err
+	}
+
+	pubKeyJSON, err := s.cosmosClient.MarshalPubKeyJSON(privateKey.PubKey())
+	if err != nil {
+		s.logger.Error(err)
+		return SignArbitraryResponse{}, err
+	}
+
+	var pubKey PubKey
+	if err = json.Unmarshal(pubKeyJSON, &pubKey); err != nil {
+		s.logger.Error(err)
+		return SignArbitraryResponse{}, err
+	}
+
+	return SignArbitraryResponse{
+		Signer:    signer,
+		Data:      input.Data,
+		PubKey:    pubKey,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+type VerifyArbitraryInput struct {
+	Signer    string `json:"signer"`
+	Data      string `json:"data"`
+	PubKey    PubKey `json:"pubKey"`
+	Signature string `json:"signature"`
+}
+
+func (input VerifyArbitraryInput) Validate() error {
+	var errs []string
+	if input.Signer == "" {
+		errs = append(errs, "invalid signer")
+	}
+
+	if input.Data == "" {
+		errs = append(errs, "invalid data")
+	}
+
+	if input.PubKey.Type == "" || input.PubKey.Value == "" {
+		errs = append(errs, "invalid pubKey")
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(input.Signature); err != nil || input.Signature == "" {
+		errs = append(errs, "invalid signature")
+	}
+
+	if len(errs) > 0 {
+		return formatErrors(errs)
+	}
+
+	return nil
+}
+
+type VerifyArbitraryResponse struct {
+	IsValid bool `json:"isValid"`
+}
+
+func (s *Service) VerifyArbitrary(ctx context.Context, input VerifyArbitraryInput) (VerifyArbitraryResponse, error) {
+	pubKeyJSON, err := json.Marshal(input.PubKey)
+	if err != nil {
+		return VerifyArbitraryResponse{}, err
+	}
+
+	pubKey, err := s.cosmosClient.UnmarshalPubKeyJSON(pubKeyJSON)
+	if err != nil {
+		return VerifyArbitraryResponse{}, err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(input.Signature)
+	if err != nil {
+		return VerifyArbitraryResponse{}, err
+	}
+
+	isValid, err := s.cosmosClient.VerifyArbitrary(pubKey, input.Signer, []byte(input.Data), signature)
+	if err != nil {
+		return VerifyArbitraryResponse{}, err
+	}
+
+	return VerifyArbitraryResponse{
+		IsValid: isValid,
+	}, nil
+}
+
+type ConvertAddressInput struct {
+	Address  string   `json:"address"`
+	Prefixes []string `json:"prefixes"`
+}
+
+func (input ConvertAddressInput) Validate() error {
+	if input.Address == "" {
+		return errors.New("invalid address")
+	}
+
+	return nil
+}
+
+type PrefixedAddress struct {
+	Prefix  string `json:"prefix"`
 	Address string `json:"address"`
 }
 
+type ConvertAddressResponse struct {
+	Address   string            `json:"address"`
+	Prefix    string            `json:"prefix"`
+	ChainIDs  []string          `json:"chainIds"`
+	Addresses []PrefixedAddress `json:"addresses"`
+}
+
+func (s *Service) ConvertAddress(ctx context.Context, input ConvertAddressInput) (ConvertAddressResponse, error) {
+	prefix, address, err := s.cosmosClient.ParseAddress(input.Address)
+	if err != nil {
+		return ConvertAddressResponse{}, err
+	}
+
+	chains, err := s.chainRepository.GetAllChains(ctx)
+	if err != nil {
+		return ConvertAddressResponse{}, err
+	}
+
+	var chainIDs []string
+	for _, chainData := range chains {
+		// hex addresses belong to ethermint based chains only
+		if (prefix == "" && chainData.Slip44 == 60) || (prefix != "" && chainData.Prefix == prefix) {
+			chainIDs = append(chainIDs, chainData.ID)
+		}
+	}
+
+	if prefix != "" && len(chainIDs) == 0 {
+		err = fmt.Errorf("address prefix %s does not belong to any registered chain", prefix)
+		return ConvertAddressResponse{}, err
+	}
+
+	prefixes := input.Prefixes
+	if len(prefixes) == 0 {
+		unique := make(map[string]struct{})
+		for _, chainData := range chains {
+			if _, ok := unique[chainData.Prefix]; ok || chainData.Prefix == "" {
+				continue
+			}
+			unique[chainData.Prefix] = struct{}{}
+			prefixes = append(prefixes, chainData.Prefix)
+		}
+	}
+
+	addresses := make([]PrefixedAddress, len(prefixes))
+	for index, chainPrefix := range prefixes {
+		converted, convertErr := s.cosmosClient.ConvertAddressPrefix(chainPrefix, address)
+		if convertErr != nil {
+			return ConvertAddressResponse{}, convertErr
+		}
+
+		addresses[index] = PrefixedAddress{
+			Prefix:  chainPrefix,
+			Address: converted,
+		}
+	}
+
+	return ConvertAddressResponse{
+		Address:   input.Address,
+		Prefix:    prefix,
+		ChainIDs:  chainIDs,
+		Addresses: addresses,
+	}, nil
+}
+
+type BalanceInput struct {
+	ChainID  string `json:"chainId"`
+	Address  string `json:"address"`
+	Currency string `json:"currency"`
+}
+
 func (input BalanceInput) Validate() error {
 	var errs []string
 	if input.ChainID == "" {
@@ -191,6 +428,15 @@ type BalanceResponse struct {
 	TotalAmount     string `json:"totalAmount"`
 	AvailableAmount string `json:"availableAmount"`
 	StakedAmount    string `json:"stakedAmount"`
+	Currency        string `json:"currency"`
+	// Price is omitted with fiat values, when price of the asset is unknown.
+	Price         *float64 `json:"price,omitempty"`
+	TotalFiat     string   `json:"totalFiat,omitempty"`
+	AvailableFiat string   `json:"availableFiat,omitempty"`
+	StakedFiat    string   `json:"stakedFiat,omitempty"`
+	// Verified means that available amount is proven by light client at VerifiedHeight instead of the latest state.
+	Verified       bool  `json:"verified"`
+	VerifiedHeight int64 `json:"verifiedHeight,omitempty"`
 }
 
 func (s *Service) CheckBalance(ctx context.Context, input BalanceInput) (BalanceResponse, error) {
@@ -219,15 +465,26 @@ func (s *Service) CheckBalance(ctx context.Context, input BalanceInput) (Balance
 	}
 
 	response := BalanceResponse{}
+	available := bankResponse.Balances.AmountOf(chainInfo.Asset.Base)
+	// balance of untrusted node is replaced by the proven one
+	if s.cosmosClient.IsVerificationEnabled(chainInfo.ID) {
+		verified, height, err := s.cosmosClient.GetVerifiedBalance(ctx, chainInfo.ID, input.Address, chainInfo.Asset.Base)
+		if err != nil {
+			s.logger.Error(err)
+			return BalanceResponse{}, err
+		}
+
+		available = verified.Amount
+		response.Verified = true
+		response.VerifiedHeight = height
+	}
+
 	for _, denomUnit := range chainInfo.Asset.DenomUnits {
 		if denomUnit.Denom == chainInfo.Asset.Display {
 			total := sdkmath.NewInt(0)
 
-			availableAmount := "0"
-			if len(bankResponse.Balances) > 0 {
-				availableAmount = chain.FromBaseToDisplay(bankResponse.Balances[0].Amount.String(), denomUnit.Exponent)
-				total = total.Add(bankResponse.Balances[0].Amount)
-			}
+			availableAmount := chain.FromBaseToDisplay(available.String(), denomUnit.Exponent)
+			total = total.Add(available)
 
 			stakedAmount := "0"
 			if len(stakingResponse.DelegationResponses) > 0 {
@@ -242,5 +499,13 @@ func (s *Service) CheckBalance(ctx context.Context, input BalanceInput) (Balance
 		}
 	}
 
+	response.Currency = s.prices.Currency(input.Currency)
+	if value, ok := s.getPrice(ctx, chainInfo.Asset, response.Currency); ok {
+		response.Price = &value
+		response.TotalFiat = price.FiatValue(response.TotalAmount, value)
+		response.AvailableFiat = price.FiatValue(response.AvailableAmount, value)
+		response.StakedFiat = price.FiatValue(response.StakedAmount, value)
+	}
+
 	return response, nil
 }
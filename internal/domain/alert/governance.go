@@ -0,0 +1,143 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Mobile-Web3/backend/internal/domain/watch"
+	"github.com/Mobile-Web3/backend/internal/notify"
+	"github.com/Mobile-Web3/backend/pkg/cosmos"
+)
+
+// votingEndingWindow is a period before the end of voting, when addresses without vote are reminded.
+const votingEndingWindow = time.Hour * 24
+
+type ProposalEvent string
+
+const (
+	ProposalVotingStarted ProposalEvent = "voting_started"
+	ProposalVotingEnding  ProposalEvent = "voting_ending"
+)
+
+// ProposalsState is a result of the last governance check of a chain.
+type ProposalsState struct {
+	// Voting are ids of proposals in voting period.
+	Voting map[uint64]struct{} `json:"voting"`
+	// Reminded are keys of watches reminded about ending voting of proposal.
+	Reminded map[string]struct{} `json:"reminded"`
+}
+
+type ProposalsRepository interface {
+	// GetProposals returns the last state of chain proposals, false is returned if chain is not checked yet.
+	GetProposals(ctx context.Context, chainID string) (ProposalsState, bool, error)
+	SaveProposals(ctx context.Context, chainID string, state ProposalsState) error
+}
+
+func reminderKey(proposalID uint64, watchID string) string {
+	return fmt.Sprintf("%d/%s", proposalID, watchID)
+}
+
+// uniqueTargets returns targets of the watches, target watching several addresses is returned once.
+func uniqueTargets(watches []watch.Watch) []notify.Target {
+	var result []notify.Target
+	for _, item := range watches {
+		found := false
+		for _, target := range result {
+			if target.Same(item.Target) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			result = append(result, item.Target)
+		}
+	}
+	return result
+}
+
+// MonitorProposals notifies registered targets about proposals entering voting period once per chain,
+// and reminds addresses, which have not voted, about voting ending soon.
+// Proposals in voting period at the first check of a chain are not notified as new ones.
+func (s *Service) MonitorProposals(ctx context.Context) error {
+	s.forEachChain(watch.AlertGovernance, func(chainID string, watches []watch.Watch) {
+		if err := s.checkProposals(ctx, chainID, watches); err != nil {
+			s.logger.Error(fmt.Errorf("checking proposals of %s; %s", chainID, err.Error()))
+		}
+	})
+
+	return nil
+}
+
+func (s *Service) checkProposals(ctx context.Context, chainID string, watches []watch.Watch) error {
+	proposals, err := s.cosmosClient.GetVotingProposals(ctx, chainID)
+	if err != nil {
+		return err
+	}
+
+	previous, checked, err := s.repository.GetProposals(ctx, chainID)
+	if err != nil {
+		return err
+	}
+
+	current := ProposalsState{
+		Voting:   make(map[uint64]struct{}, len(proposals)),
+		Reminded: make(map[string]struct{}),
+	}
+	chainName := s.chainName(ctx, chainID)
+	now := time.Now()
+	for _, proposal := range proposals {
+		current.Voting[proposal.ID] = struct{}{}
+		if _, ok := previous.Voting[proposal.ID]; checked && !ok {
+			for _, target := range uniqueTargets(watches) {
+				s.notify(ctx, target, proposalMessage(chainID, chainName, "", proposal, ProposalVotingStarted))
+			}
+		}
+
+		if proposal.VotingEndTime.IsZero() || proposal.VotingEndTime.Sub(now) > votingEndingWindow {
+			continue
+		}
+
+		for _, item := range watches {
+			key := reminderKey(proposal.ID, item.ID)
+			if _, ok := previous.Reminded[key]; ok {
+				current.Reminded[key] = struct{}{}
+				continue
+			}
+
+			voted, voteErr := s.cosmosClient.HasVoted(ctx, chainID, proposal.ID, item.Address)
+			if voteErr != nil {
+				s.logger.Error(voteErr)
+				continue
+			}
+
+			current.Reminded[key] = struct{}{}
+			if !voted {
+				s.notify(ctx, item.Target, proposalMessage(chainID, chainName, item.Address, proposal, ProposalVotingEnding))
+			}
+		}
+	}
+
+	return s.repository.SaveProposals(ctx, chainID, current)
+}
+
+// proposalMessage is a message about proposal, address is empty when message concerns all addresses of the target.
+func proposalMessage(chainID string, chainName string, address string, proposal cosmos.Proposal, event ProposalEvent) notify.Message {
+	message := notify.Message{
+		Type: "proposal_alert",
+		Data: map[string]string{
+			"chainId":       chainID,
+			"chainName":     chainName,
+			"proposalId":    fmt.Sprintf("%d", proposal.ID),
+			"title":         proposal.Title,
+			"status":        string(event),
+			"votingEndTime": proposal.VotingEndTime.UTC().Format(time.RFC3339),
+		},
+	}
+
+	if address != "" {
+		message.Data["address"] = address
+	}
+	return message
+}
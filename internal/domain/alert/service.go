@@ -0,0 +1,83 @@
+package alert
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/internal/domain/watch"
+	"github.com/Mobile-Web3/backend/internal/notify"
+	"github.com/Mobile-Web3/backend/pkg/cosmos"
+	"github.com/Mobile-Web3/backend/pkg/log"
+)
+
+const monitorParallelism = 8
+
+type Repository interface {
+	ValidatorsRepository
+	ProposalsRepository
+}
+
+// Service checks chain state of registered addresses periodically and notifies about changes.
+type Service struct {
+	logger          log.Logger
+	chainRepository chain.Repository
+	repository      Repository
+	cosmosClient    *cosmos.Client
+	watchService    *watch.Service
+	notifier        notify.Notifier
+}
+
+func NewService(
+	logger log.Logger,
+	chainRepository chain.Repository,
+	repository Repository,
+	cosmosClient *cosmos.Client,
+	watchService *watch.Service,
+	notifier notify.Notifier) *Service {
+	return &Service{
+		logger:          logger,
+		chainRepository: chainRepository,
+		repository:      repository,
+		cosmosClient:    cosmosClient,
+		watchService:    watchService,
+		notifier:        notifier,
+	}
+}
+
+// forEachChain groups watches of the alert by chain and handles chains in parallel.
+func (s *Service) forEachChain(alert watch.Alert, handle func(chainID string, watches []watch.Watch)) {
+	chains := make(map[string][]watch.Watch)
+	for _, item := range s.watchService.GetWatches(alert) {
+		chains[item.ChainID] = append(chains[item.ChainID], item)
+	}
+
+	semaphore := make(chan struct{}, monitorParallelism)
+	wg := &sync.WaitGroup{}
+	for chainID, watches := range chains {
+		wg.Add(1)
+		go func(chainID string, watches []watch.Watch) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			handle(chainID, watches)
+		}(chainID, watches)
+	}
+
+	wg.Wait()
+}
+
+func (s *Service) chainName(ctx context.Context, chainID string) string {
+	chainData, err := s.chainRepository.GetByID(ctx, chainID)
+	if err != nil || chainData.PrettyName == "" {
+		return chainID
+	}
+
+	return chainData.PrettyName
+}
+
+func (s *Service) notify(ctx context.Context, target notify.Target, message notify.Message) {
+	if err := s.notifier.Notify(ctx, target, message); err != nil {
+		s.logger.Error(err)
+	}
+}
@@ -0,0 +1,189 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mobile-Web3/backend/internal/domain/watch"
+	"github.com/Mobile-Web3/backend/internal/notify"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+type ValidatorEvent string
+
+const (
+	ValidatorJailed     ValidatorEvent = "jailed"
+	ValidatorTombstoned ValidatorEvent = "tombstoned"
+	ValidatorInactive   ValidatorEvent = "inactive"
+	ValidatorCommission ValidatorEvent = "commission"
+)
+
+// ValidatorState is a snapshot of validator fields, which changes are alerted.
+type ValidatorState struct {
+	OperatorAddress string  `json:"operatorAddress"`
+	Moniker         string  `json:"moniker"`
+	Jailed          bool    `json:"jailed"`
+	Tombstoned      bool    `json:"tombstoned"`
+	Bonded          bool    `json:"bonded"`
+	Commission      sdk.Dec `json:"commission"`
+}
+
+type ValidatorsRepository interface {
+	// GetValidators returns the last snapshot of chain validators, false is returned if chain is not checked yet.
+	GetValidators(ctx context.Context, chainID string) (map[string]ValidatorState, bool, error)
+	SaveValidators(ctx context.Context, chainID string, validators map[string]ValidatorState) error
+}
+
+type validatorChange struct {
+	state         ValidatorState
+	event         ValidatorEvent
+	oldCommission sdk.Dec
+}
+
+// MonitorValidators compares validators of chains with registered delegators to the previous check
+// and notifies delegators of the changed validators. The first check of a chain only saves the snapshot.
+func (s *Service) MonitorValidators(ctx context.Context) error {
+	s.forEachChain(watch.AlertValidators, func(chainID string, watches []watch.Watch) {
+		if err := s.checkValidators(ctx, chainID, watches); err != nil {
+			s.logger.Error(fmt.Errorf("checking validators of %s; %s", chainID, err.Error()))
+		}
+	})
+
+	return nil
+}
+
+func (s *Service) checkValidators(ctx context.Context, chainID string, watches []watch.Watch) error {
+	validators, err := s.cosmosClient.GetValidators(ctx, chainID, "")
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]ValidatorState, len(validators))
+	for _, info := range validators {
+		validator := info.Validator
+		current[validator.OperatorAddress] = ValidatorState{
+			OperatorAddress: validator.OperatorAddress,
+			Moniker:         validator.Description.Moniker,
+			Jailed:          validator.Jailed,
+			Tombstoned:      info.SigningInfo != nil && info.SigningInfo.Tombstoned,
+			Bonded:          validator.IsBonded(),
+			Commission:      validator.Commission.Rate,
+		}
+	}
+
+	previous, checked, err := s.repository.GetValidators(ctx, chainID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repository.SaveValidators(ctx, chainID, current); err != nil {
+		return err
+	}
+
+	if !checked {
+		return nil
+	}
+
+	changes := make(map[string][]validatorChange)
+	for address, state := range current {
+		old, ok := previous[address]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case state.Tombstoned && !old.Tombstoned:
+			changes[address] = append(changes[address], validatorChange{state: state, event: ValidatorTombstoned})
+		case state.Jailed && !old.Jailed:
+			changes[address] = append(changes[address], validatorChange{state: state, event: ValidatorJailed})
+		case !state.Bonded && old.Bonded && !state.Jailed:
+			changes[address] = append(changes[address], validatorChange{state: state, event: ValidatorInactive})
+		}
+
+		if !old.Commission.IsNil() && !state.Commission.IsNil() && state.Commission.GT(old.Commission) {
+			changes[address] = append(changes[address], validatorChange{state: state, event: ValidatorCommission, oldCommission: old.Commission})
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	chainName := s.chainName(ctx, chainID)
+	delegations := make(map[string]map[string]struct{})
+	for _, item := range watches {
+		validatorAddresses, ok := delegations[item.Address]
+		if !ok {
+			validatorAddresses, err = s.getDelegatedValidators(ctx, chainID, item.Address)
+			if err != nil {
+				s.logger.Error(err)
+				continue
+			}
+			delegations[item.Address] = validatorAddresses
+		}
+
+		for validatorAddress := range validatorAddresses {
+			for _, change := range changes[validatorAddress] {
+				s.notify(ctx, item.Target, validatorMessage(chainID, chainName, item.Address, change))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) getDelegatedValidators(ctx context.Context, chainID string, delegator string) (map[string]struct{}, error) {
+	client := staking.NewQueryClient(s.cosmosClient.GetChainGrpcClient(chainID))
+	result := make(map[string]struct{})
+	var nextKey []byte
+	for {
+		response, err := client.DelegatorDelegations(ctx, &staking.QueryDelegatorDelegationsRequest{
+			DelegatorAddr: delegator,
+			Pagination: &query.PageRequest{
+				Key: nextKey,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, delegation := range response.DelegationResponses {
+			result[delegation.Delegation.ValidatorAddress] = struct{}{}
+		}
+
+		if response.Pagination == nil || len(response.Pagination.NextKey) == 0 {
+			return result, nil
+		}
+		nextKey = response.Pagination.NextKey
+	}
+}
+
+func formatCommission(rate sdk.Dec) string {
+	if rate.IsNil() {
+		return ""
+	}
+
+	value, err := rate.MulInt64(100).Float64()
+	if err != nil {
+		return rate.String()
+	}
+	return fmt.Sprintf("%.1f%%", value)
+}
+
+func validatorMessage(chainID string, chainName string, address string, change validatorChange) notify.Message {
+	return notify.Message{
+		Type: "validator_alert",
+		Data: map[string]string{
+			"chainId":          chainID,
+			"chainName":        chainName,
+			"address":          address,
+			"validator":        change.state.Moniker,
+			"validatorAddress": change.state.OperatorAddress,
+			"status":           string(change.event),
+			"oldCommission":    formatCommission(change.oldCommission),
+			"newCommission":    formatCommission(change.state.Commission),
+		},
+	}
+}
@@ -0,0 +1,178 @@
+package avatar
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mobile-Web3/backend/pkg/log"
+)
+
+const (
+	queueSize       = 2048
+	requestInterval = time.Millisecond * 250
+	requestTimeout  = time.Second * 10
+	// errorRetryDelay prevents requests of the identity right after provider failure.
+	errorRetryDelay = time.Minute * 10
+)
+
+// Provider resolves validator identity to profile picture url, empty url is returned if identity has no picture.
+type Provider interface {
+	GetAvatarURL(ctx context.Context, identity string) (string, error)
+}
+
+// Avatar is a resolved identity, URL is empty if identity has no picture.
+type Avatar struct {
+	Identity  string    `json:"identity"`
+	URL       string    `json:"url"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]Avatar, error)
+	Save(ctx context.Context, avatar Avatar) error
+}
+
+// Service returns cached avatars and resolves missing and expired ones in background one by one,
+// so provider rate limits are not exceeded.
+type Service struct {
+	logger     log.Logger
+	provider   Provider
+	repository Repository
+	ttl        time.Duration
+	mutex      sync.RWMutex
+	avatars    map[string]Avatar
+	queued     map[string]struct{}
+	failures   map[string]time.Time
+	queue      chan string
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+func NewService(logger log.Logger, provider Provider, repository Repository, ttl time.Duration) *Service {
+	return &Service{
+		logger:     logger,
+		provider:   provider,
+		repository: repository,
+		ttl:        ttl,
+		avatars:    make(map[string]Avatar),
+		queued:     make(map[string]struct{}),
+		failures:   make(map[string]time.Time),
+		queue:      make(chan string, queueSize),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Start loads cached avatars and starts background resolver.
+func (s *Service) Start(ctx context.Context) error {
+	avatars, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	for _, item := range avatars {
+		s.avatars[item.Identity] = item
+	}
+	s.mutex.Unlock()
+
+	go s.resolve()
+	return nil
+}
+
+func (s *Service) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+}
+
+func normalizeIdentity(identity string) string {
+	return strings.ToUpper(strings.TrimSpace(identity))
+}
+
+// GetAvatarURL returns cached avatar url and queues resolution of missing and expired avatars, so it never waits for provider.
+func (s *Service) GetAvatarURL(identity string) string {
+	identity = normalizeIdentity(identity)
+	if identity == "" {
+		return ""
+	}
+
+	s.mutex.RLock()
+	avatar, ok := s.avatars[identity]
+	s.mutex.RUnlock()
+
+	if !ok || time.Since(avatar.UpdatedAt) > s.ttl {
+		s.enqueue(identity)
+	}
+
+	return avatar.URL
+}
+
+func (s *Service) enqueue(identity string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.queued[identity]; ok {
+		return
+	}
+	if failedAt, ok := s.failures[identity]; ok && time.Since(failedAt) < errorRetryDelay {
+		return
+	}
+
+	// identity is requested again later, if queue is full
+	select {
+	case s.queue <- identity:
+		s.queued[identity] = struct{}{}
+	default:
+	}
+}
+
+func (s *Service) resolve() {
+	ticker := time.NewTicker(requestInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case identity := <-s.queue:
+			s.resolveIdentity(identity)
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) resolveIdentity(identity string) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	url, err := s.provider.GetAvatarURL(ctx, identity)
+
+	s.mutex.Lock()
+	delete(s.queued, identity)
+	if err != nil {
+		s.failures[identity] = time.Now()
+		s.mutex.Unlock()
+		s.logger.Error(err)
+		return
+	}
+
+	avatar := Avatar{
+		Identity:  identity,
+		URL:       url,
+		UpdatedAt: time.Now(),
+	}
+	delete(s.failures, identity)
+	s.avatars[identity] = avatar
+	s.mutex.Unlock()
+
+	if err = s.repository.Save(ctx, avatar); err != nil {
+		s.logger.Error(err)
+	}
+}
@@ -36,7 +36,9 @@ type Rpc struct {
 }
 
 type Api struct {
-	Rpc []Rpc `json:"rpc"`
+	Rpc  []Rpc `json:"rpc"`
+	Grpc []Rpc `json:"grpc"`
+	Rest []Rpc `json:"rest"`
 }
 
 type DenomUnit struct {
@@ -54,6 +56,7 @@ type Asset struct {
 	Base        string      `json:"base"`
 	Symbol      string      `json:"symbol"`
 	Display     string      `json:"display"`
+	CoingeckoID string      `json:"coingecko_id"`
 	Logo        Logo        `json:"logo_URIs"`
 	DenomUnits  []DenomUnit `json:"denom_units"`
 }
@@ -123,6 +126,21 @@ func FromBaseToDisplay(amount string, exponent int) string {
 	}
 }
 
+// FormatDisplayAmount converts base amount to display one without trailing zeros and appends the asset symbol.
+func FormatDisplayAmount(amount string, asset Asset) (string, error) {
+	_, exponent, err := GetBaseDenom(asset.Base, asset.Display, asset.DenomUnits)
+	if err != nil {
+		return "", err
+	}
+
+	result := FromBaseToDisplay(amount, exponent)
+	if strings.Contains(result, ".") {
+		result = strings.TrimRight(strings.TrimRight(result, "0"), ".")
+	}
+
+	return fmt.Sprintf("%s %s", result, asset.Symbol), nil
+}
+
 func FromDisplayToBase(amount string, denom string, exponent int) (string, error) {
 	var sb strings.Builder
 	if !strings.Contains(amount, ".") {
@@ -222,3 +240,38 @@ func ValidateRPCUrls(rpc []Rpc) ([]Rpc, error) {
 
 	return result, nil
 }
+
+// ValidateGRPCUrls converts grpc endpoints to host:port form, port is required unless scheme is given.
+func ValidateGRPCUrls(grpc []Rpc) []Rpc {
+	var result []Rpc
+	for _, endpoint := range grpc {
+		address := endpoint.Address
+		if !strings.Contains(address, "://") {
+			address = "tcp://" + address
+		}
+
+		u, err := url.Parse(address)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+
+		port := u.Port()
+		if port == "" {
+			switch u.Scheme {
+			case "https":
+				port = "443"
+			case "http":
+				port = "80"
+			default:
+				continue
+			}
+		}
+
+		result = append(result, Rpc{
+			Provider: endpoint.Provider,
+			Address:  fmt.Sprintf("%s:%s", u.Hostname(), port),
+		})
+	}
+
+	return result
+}
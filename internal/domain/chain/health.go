@@ -0,0 +1,127 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Mobile-Web3/backend/internal/metrics"
+)
+
+const (
+	monitorParallelism = 8
+	// endpointsCheckTimeout limits probe of a single chain, so slow chains don't exhaust time of the others.
+	endpointsCheckTimeout = time.Second * 10
+)
+
+type EndpointHealth struct {
+	Endpoint    string    `json:"endpoint"`
+	IsHealthy   bool      `json:"isHealthy"`
+	CatchingUp  bool      `json:"catchingUp"`
+	Height      int64     `json:"height"`
+	HeightLag   int64     `json:"heightLag"`
+	LatencyMs   int64     `json:"latencyMs"`
+	ErrorRate   float64   `json:"errorRate"`
+	CircuitOpen bool      `json:"circuitOpen"`
+	CheckedAt   time.Time `json:"checkedAt"`
+}
+
+type EndpointsHealth struct {
+	ChainID   string           `json:"chainId"`
+	Healthy   int              `json:"healthy"`
+	Total     int              `json:"total"`
+	Error     string           `json:"error,omitempty"`
+	CheckedAt time.Time        `json:"checkedAt"`
+	Endpoints []EndpointHealth `json:"endpoints"`
+}
+
+type HealthRepository interface {
+	GetEndpointsHealth(ctx context.Context, chainID string) (EndpointsHealth, error)
+	UpdateEndpointsHealth(ctx context.Context, health EndpointsHealth) error
+}
+
+// MonitorEndpoints probes rpc endpoints of every chain and stores their health.
+// Chains, which are not probed before ctx is done, are skipped until the next run.
+func (s *Service) MonitorEndpoints(ctx context.Context) error {
+	chains, err := s.repository.GetAllChains(ctx)
+	if err != nil {
+		return err
+	}
+
+	semaphore := make(chan struct{}, monitorParallelism)
+	wg := &sync.WaitGroup{}
+	for _, chainData := range chains {
+		wg.Add(1)
+		go func(chainID string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			if ctx.Err() != nil {
+				return
+			}
+			s.checkEndpoints(ctx, chainID)
+		}(chainData.ID)
+	}
+
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (s *Service) checkEndpoints(ctx context.Context, chainID string) {
+	ctx, cancel := context.WithTimeout(ctx, endpointsCheckTimeout)
+	defer cancel()
+
+	// endpoints, which probes are interrupted by timeout, keep their previous stats
+	statuses, err := s.cosmosClient.CheckEndpoints(ctx, chainID)
+
+	health := EndpointsHealth{
+		ChainID:   chainID,
+		Total:     len(statuses),
+		CheckedAt: time.Now(),
+		Endpoints: make([]EndpointHealth, len(statuses)),
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+
+	for index, status := range statuses {
+		if status.Available {
+			health.Healthy++
+		}
+		health.Endpoints[index] = EndpointHealth{
+			Endpoint:    status.Endpoint,
+			IsHealthy:   status.Available,
+			CatchingUp:  status.CatchingUp,
+			Height:      status.Height,
+			HeightLag:   status.HeightLag,
+			LatencyMs:   status.Latency.Milliseconds(),
+			ErrorRate:   status.ErrorRate,
+			CircuitOpen: status.CircuitOpen,
+			CheckedAt:   status.CheckedAt,
+		}
+	}
+
+	metrics.Endpoints.Set(chainID, health.Healthy, health.Total)
+	_ = s.healthRepository.UpdateEndpointsHealth(context.Background(), health)
+}
+
+type EndpointsInput struct {
+	ChainID string
+}
+
+func (input EndpointsInput) Validate() error {
+	if input.ChainID == "" {
+		return fmt.Errorf("invalid chainId")
+	}
+
+	return nil
+}
+
+func (s *Service) GetEndpointsHealth(ctx context.Context, input EndpointsInput) (EndpointsHealth, error) {
+	if _, err := s.repository.GetByID(ctx, input.ChainID); err != nil {
+		return EndpointsHealth{}, err
+	}
+
+	return s.healthRepository.GetEndpointsHealth(ctx, input.ChainID)
+}
@@ -22,4 +22,6 @@ type Repository interface {
 	GetByID(ctx context.Context, chainID string) (Chain, error)
 	UpdateChains(ctx context.Context, chains []Chain) error
 	GetRPCEndpoints(ctx context.Context, chainID string) ([]string, error)
+	GetGRPCEndpoints(ctx context.Context, chainID string) ([]string, error)
+	GetRESTEndpoints(ctx context.Context, chainID string) ([]string, error)
 }
@@ -0,0 +1,231 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Mobile-Web3/backend/pkg/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distribution "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	mint "github.com/cosmos/cosmos-sdk/x/mint/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// stakingCacheTTL is a lifetime of chain staking info, it changes slowly.
+const stakingCacheTTL = time.Minute * 5
+
+// blockTimeSamples are counts of the last blocks, which average time is used for real apr.
+// Smaller samples are used, when pruned node doesn't have older blocks.
+var blockTimeSamples = []int64{5000, 1000, 100}
+
+type StakingInput struct {
+	ChainID string
+}
+
+func (input StakingInput) Validate() error {
+	if input.ChainID == "" {
+		return errors.New("invalid chainId")
+	}
+
+	return nil
+}
+
+type ValidatorAPR struct {
+	Address    string `json:"address"`
+	Name       string `json:"name"`
+	Commission string `json:"commission"`
+	// APR is a real apr of delegators net of validator commission, empty if it can't be calculated.
+	APR string `json:"apr"`
+}
+
+type stakingSnapshot struct {
+	response  StakingResponse
+	updatedAt time.Time
+}
+
+// StakingResponse has empty inflation, provisions and apr for chains without the standard mint module, e.g. Osmosis and Evmos.
+type StakingResponse struct {
+	BondDenom        string `json:"bondDenom"`
+	Inflation        string `json:"inflation"`
+	AnnualProvisions string `json:"annualProvisions"`
+	CommunityTax     string `json:"communityTax"`
+	BondedTokens     string `json:"bondedTokens"`
+	TotalSupply      string `json:"totalSupply"`
+	BondedRatio      string `json:"bondedRatio"`
+	// NominalAPR is calculated with blocks per year from mint params.
+	NominalAPR string `json:"nominalApr"`
+	// RealAPR is calculated with blocks per year measured by actual block time, it equals to NominalAPR,
+	// if block time can't be measured.
+	RealAPR             string `json:"realApr"`
+	BlocksPerYear       uint64 `json:"blocksPerYear"`
+	ActualBlocksPerYear uint64 `json:"actualBlocksPerYear"`
+	// UnbondingTime is in seconds.
+	UnbondingTime int64          `json:"unbondingTime"`
+	MaxValidators uint32         `json:"maxValidators"`
+	Validators    []ValidatorAPR `json:"validators"`
+}
+
+// mintInfo is state of the standard mint module, chains with custom minting (Osmosis, Evmos) don't have it.
+type mintInfo struct {
+	inflation     sdk.Dec
+	provisions    sdk.Dec
+	blocksPerYear uint64
+}
+
+func (s *Service) getMintInfo(ctx context.Context, chainID string) (mintInfo, error) {
+	mintClient := mint.NewQueryClient(s.cosmosClient.GetChainGrpcClient(chainID))
+	params, err := mintClient.Params(ctx, &mint.QueryParamsRequest{})
+	if err != nil {
+		return mintInfo{}, err
+	}
+
+	inflation, err := mintClient.Inflation(ctx, &mint.QueryInflationRequest{})
+	if err != nil {
+		return mintInfo{}, err
+	}
+
+	provisions, err := mintClient.AnnualProvisions(ctx, &mint.QueryAnnualProvisionsRequest{})
+	if err != nil {
+		return mintInfo{}, err
+	}
+
+	return mintInfo{
+		inflation:     inflation.Inflation,
+		provisions:    provisions.AnnualProvisions,
+		blocksPerYear: params.Params.BlocksPerYear,
+	}, nil
+}
+
+// getActualBlocksPerYear measures block time by the largest sample available on pruned nodes.
+func (s *Service) getActualBlocksPerYear(ctx context.Context, chainID string) (uint64, error) {
+	var err error
+	for _, sample := range blockTimeSamples {
+		var blocksPerYear uint64
+		blocksPerYear, err = s.cosmosClient.GetBlocksPerYear(ctx, chainID, sample)
+		if err == nil {
+			return blocksPerYear, nil
+		}
+	}
+
+	return 0, err
+}
+
+func (s *Service) GetStaking(ctx context.Context, input StakingInput) (StakingResponse, error) {
+	s.stakingMutex.Lock()
+	cached, ok := s.staking[input.ChainID]
+	s.stakingMutex.Unlock()
+	if ok && time.Since(cached.updatedAt) < stakingCacheTTL {
+		return cached.response, nil
+	}
+
+	chainData, err := s.repository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return StakingResponse{}, err
+	}
+
+	_, exponent, err := GetBaseDenom(chainData.Asset.Base, chainData.Asset.Display, chainData.Asset.DenomUnits)
+	if err != nil {
+		return StakingResponse{}, err
+	}
+
+	connection := s.cosmosClient.GetChainGrpcClient(input.ChainID)
+	distributionParams, err := distribution.NewQueryClient(connection).Params(ctx, &distribution.QueryParamsRequest{})
+	if err != nil {
+		return StakingResponse{}, err
+	}
+
+	stakingParams, err := staking.NewQueryClient(connection).Params(ctx, &staking.QueryParamsRequest{})
+	if err != nil {
+		return StakingResponse{}, err
+	}
+
+	supply, err := bank.NewQueryClient(connection).SupplyOf(ctx, &bank.QuerySupplyOfRequest{
+		Denom: stakingParams.Params.BondDenom,
+	})
+	if err != nil {
+		return StakingResponse{}, err
+	}
+
+	snapshot, err := s.getValidators(ctx, input.ChainID)
+	if err != nil {
+		return StakingResponse{}, err
+	}
+
+	communityTax := distributionParams.Params.CommunityTax
+	bondedTokens := snapshot.bondedTokens
+	totalSupply := supply.Amount.Amount
+
+	bondedRatio := sdk.ZeroDec()
+	if totalSupply.IsPositive() {
+		bondedRatio = sdk.NewDecFromInt(bondedTokens).QuoInt(totalSupply)
+	}
+
+	result := StakingResponse{
+		BondDenom:     stakingParams.Params.BondDenom,
+		CommunityTax:  formatPercent(communityTax, 2),
+		BondedTokens:  FromBaseToDisplay(bondedTokens.String(), exponent),
+		TotalSupply:   FromBaseToDisplay(totalSupply.String(), exponent),
+		BondedRatio:   formatPercent(bondedRatio, 2),
+		UnbondingTime: int64(stakingParams.Params.UnbondingTime.Seconds()),
+		MaxValidators: stakingParams.Params.MaxValidators,
+	}
+
+	// apr is not calculated for chains without the standard mint module, other fields are still returned
+	var realAPR *sdk.Dec
+	if mintData, err := s.getMintInfo(ctx, input.ChainID); err == nil {
+		// rewards of delegators are annual provisions without community tax divided by bonded tokens
+		nominalAPR := sdk.ZeroDec()
+		if bondedTokens.IsPositive() {
+			nominalAPR = mintData.provisions.Mul(sdk.OneDec().Sub(communityTax)).QuoInt(bondedTokens)
+		}
+
+		// provisions are minted per block, so chain with blocks slower than in params mints less than annual provisions
+		apr := nominalAPR
+		actualBlocksPerYear, err := s.getActualBlocksPerYear(ctx, input.ChainID)
+		if err == nil && mintData.blocksPerYear > 0 {
+			apr = nominalAPR.MulInt64(int64(actualBlocksPerYear)).QuoInt64(int64(mintData.blocksPerYear))
+		}
+		realAPR = &apr
+
+		result.Inflation = formatPercent(mintData.inflation, 2)
+		result.AnnualProvisions = FromBaseToDisplay(mintData.provisions.TruncateInt().String(), exponent)
+		result.NominalAPR = formatPercent(nominalAPR, 2)
+		result.RealAPR = formatPercent(apr, 2)
+		result.BlocksPerYear = mintData.blocksPerYear
+		result.ActualBlocksPerYear = actualBlocksPerYear
+	}
+
+	// only bonded validators receive rewards
+	var validators []cosmos.ValidatorInfo
+	for _, info := range snapshot.validators {
+		if info.Validator.IsBonded() {
+			validators = append(validators, info)
+		}
+	}
+	sortValidators(validators, ValidatorSortVotingPower, false)
+
+	result.Validators = make([]ValidatorAPR, 0, len(validators))
+	for _, info := range validators {
+		commission := info.Validator.Commission.Rate
+		validator := ValidatorAPR{
+			Address:    info.Validator.OperatorAddress,
+			Name:       info.Validator.Description.Moniker,
+			Commission: formatPercent(commission, 2),
+		}
+		if realAPR != nil {
+			validator.APR = formatPercent(realAPR.Mul(sdk.OneDec().Sub(commission)), 2)
+		}
+		result.Validators = append(result.Validators, validator)
+	}
+
+	s.stakingMutex.Lock()
+	s.staking[input.ChainID] = stakingSnapshot{
+		response:  result,
+		updatedAt: time.Now(),
+	}
+	s.stakingMutex.Unlock()
+
+	return result, nil
+}
@@ -0,0 +1,423 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mobile-Web3/backend/pkg/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// validatorsCacheTTL is a lifetime of chain validators snapshot, it is shared by all pages and filters.
+const validatorsCacheTTL = time.Minute
+
+const (
+	ValidatorStatusAll       = "all"
+	ValidatorStatusBonded    = "bonded"
+	ValidatorStatusUnbonding = "unbonding"
+	ValidatorStatusUnbonded  = "unbonded"
+)
+
+const (
+	ValidatorSortVotingPower = "voting_power"
+	ValidatorSortCommission  = "commission"
+	ValidatorSortName        = "name"
+	ValidatorSortUptime      = "uptime"
+)
+
+var validatorStatuses = map[staking.BondStatus]string{
+	staking.Bonded:    ValidatorStatusBonded,
+	staking.Unbonding: ValidatorStatusUnbonding,
+	staking.Unbonded:  ValidatorStatusUnbonded,
+}
+
+// AvatarResolver returns profile picture url of validator identity, empty url is returned until it is resolved.
+type AvatarResolver interface {
+	GetAvatarURL(identity string) string
+}
+
+type validatorsSnapshot struct {
+	validators   []cosmos.ValidatorInfo
+	bondedTokens sdk.Int
+	// signedBlocksWindow is a count of the last blocks, where missed blocks are counted, zero if it is unknown.
+	signedBlocksWindow int64
+	updatedAt          time.Time
+}
+
+type Validator struct {
+	Address            string `json:"address"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	Identity           string `json:"identity"`
+	AvatarURL          string `json:"avatarUrl"`
+	Tokens             string `json:"tokens"`
+	Commission         string `json:"commission"`
+	Website            string `json:"website"`
+	Status             string `json:"status"`
+	Jailed             bool   `json:"jailed"`
+	VotingPowerPercent string `json:"votingPowerPercent"`
+	// Uptime is a percent of signed blocks in the signed blocks window, empty if signing info is unknown.
+	Uptime             string     `json:"uptime"`
+	MissedBlocks       int64      `json:"missedBlocks"`
+	SignedBlocksWindow int64      `json:"signedBlocksWindow"`
+	Tombstoned         bool       `json:"tombstoned"`
+	JailedUntil        *time.Time `json:"jailedUntil,omitempty"`
+}
+
+// maxValidatorsLimit is a max count of validators returned by one page.
+const maxValidatorsLimit = 100
+
+type PagedValidatorsInput struct {
+	ChainID string
+	Limit   uint64
+	Offset  uint64
+	// Status is bonded, unbonding, unbonded or all, bonded by default.
+	Status string
+	// Sort is voting_power, commission, name or uptime, voting_power by default.
+	Sort string
+	// Desc sorts in descending order.
+	Desc bool
+	// Search filters validators by moniker.
+	Search string
+}
+
+func (input PagedValidatorsInput) Validate() error {
+	var errs []string
+	if input.ChainID == "" {
+		errs = append(errs, "invalid chainId")
+	}
+
+	if input.Limit == 0 || input.Limit > maxValidatorsLimit {
+		errs = append(errs, fmt.Sprintf("invalid limit, max %d", maxValidatorsLimit))
+	}
+
+	switch input.Status {
+	case "", ValidatorStatusAll, ValidatorStatusBonded, ValidatorStatusUnbonding, ValidatorStatusUnbonded:
+	default:
+		errs = append(errs, "invalid status")
+	}
+
+	switch input.Sort {
+	case "", ValidatorSortVotingPower, ValidatorSortCommission, ValidatorSortName, ValidatorSortUptime:
+	default:
+		errs = append(errs, "invalid sort")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+type PagedValidatorsResponse struct {
+	Limit  uint64      `json:"limit"`
+	Offset uint64      `json:"offset"`
+	Total  int         `json:"total"`
+	Data   []Validator `json:"data"`
+}
+
+// getValidators returns validators of all statuses and bonded tokens of the chain from cache or from chain.
+func (s *Service) getValidators(ctx context.Context, chainID string) (validatorsSnapshot, error) {
+	s.validatorsMutex.Lock()
+	snapshot, ok := s.validators[chainID]
+	s.validatorsMutex.Unlock()
+	if ok && time.Since(snapshot.updatedAt) < validatorsCacheTTL {
+		return snapshot, nil
+	}
+
+	validators, err := s.cosmosClient.GetValidators(ctx, chainID, "")
+	if err != nil {
+		return validatorsSnapshot{}, err
+	}
+
+	pool, err := staking.NewQueryClient(s.cosmosClient.GetChainGrpcClient(chainID)).Pool(ctx, &staking.QueryPoolRequest{})
+	if err != nil {
+		return validatorsSnapshot{}, err
+	}
+
+	snapshot = validatorsSnapshot{
+		validators:   validators,
+		bondedTokens: pool.Pool.BondedTokens,
+		updatedAt:    time.Now(),
+	}
+
+	// uptime is optional, so validators are returned without it, if slashing params are not available
+	params, err := slashing.NewQueryClient(s.cosmosClient.GetChainGrpcClient(chainID)).Params(ctx, &slashing.QueryParamsRequest{})
+	if err == nil {
+		snapshot.signedBlocksWindow = params.Params.SignedBlocksWindow
+	}
+
+	// avatars of all validators are resolved in background, so the following requests return them
+	for index := range validators {
+		s.avatars.GetAvatarURL(validators[index].Validator.Description.Identity)
+	}
+
+	s.validatorsMutex.Lock()
+	s.validators[chainID] = snapshot
+	s.validatorsMutex.Unlock()
+	return snapshot, nil
+}
+
+func formatPercent(value sdk.Dec, precision int) string {
+	result, err := value.MulInt64(100).Float64()
+	if err != nil {
+		return value.String()
+	}
+	return fmt.Sprintf("%.*f", precision, result)
+}
+
+func (s *Service) newValidator(info cosmos.ValidatorInfo, snapshot validatorsSnapshot, exponent int) Validator {
+	validator := info.Validator
+	votingPower := sdk.ZeroDec()
+	if validator.IsBonded() && snapshot.bondedTokens.IsPositive() {
+		votingPower = sdk.NewDecFromInt(validator.Tokens).QuoInt(snapshot.bondedTokens)
+	}
+
+	result := Validator{
+		Address:            validator.OperatorAddress,
+		Name:               validator.Description.Moniker,
+		Description:        validator.Description.Details,
+		Identity:           validator.Description.Identity,
+		AvatarURL:          s.avatars.GetAvatarURL(validator.Description.Identity),
+		Tokens:             FromBaseToDisplay(validator.Tokens.String(), exponent),
+		Commission:         formatPercent(validator.Commission.Rate, 1),
+		Website:            validator.Description.Website,
+		Status:             validatorStatuses[validator.Status],
+		Jailed:             validator.Jailed,
+		VotingPowerPercent: formatPercent(votingPower, 2),
+		SignedBlocksWindow: snapshot.signedBlocksWindow,
+	}
+
+	if info.SigningInfo == nil {
+		return result
+	}
+
+	result.MissedBlocks = info.SigningInfo.MissedBlocksCounter
+	result.Tombstoned = info.SigningInfo.Tombstoned
+	// jailed until time is zero unix time for validators, which have never been jailed
+	if info.SigningInfo.JailedUntil.Unix() > 0 {
+		jailedUntil := info.SigningInfo.JailedUntil
+		result.JailedUntil = &jailedUntil
+	}
+
+	if snapshot.signedBlocksWindow > 0 {
+		missed := sdk.NewDec(info.SigningInfo.MissedBlocksCounter).QuoInt64(snapshot.signedBlocksWindow)
+		result.Uptime = formatPercent(sdk.OneDec().Sub(missed), 2)
+	}
+
+	return result
+}
+
+func (s *Service) GetPagedValidators(ctx context.Context, input PagedValidatorsInput) (PagedValidatorsResponse, error) {
+	chainData, err := s.repository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return PagedValidatorsResponse{}, err
+	}
+
+	_, exponent, err := GetBaseDenom(chainData.Asset.Base, chainData.Asset.Display, chainData.Asset.DenomUnits)
+	if err != nil {
+		return PagedValidatorsResponse{}, err
+	}
+
+	snapshot, err := s.getValidators(ctx, input.ChainID)
+	if err != nil {
+		return PagedValidatorsResponse{}, err
+	}
+
+	status := input.Status
+	if status == "" {
+		status = ValidatorStatusBonded
+	}
+	search := strings.ToLower(strings.TrimSpace(input.Search))
+
+	var validators []cosmos.ValidatorInfo
+	for _, info := range snapshot.validators {
+		if status != ValidatorStatusAll && validatorStatuses[info.Validator.Status] != status {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(info.Validator.Description.Moniker), search) {
+			continue
+		}
+		validators = append(validators, info)
+	}
+
+	sortValidators(validators, input.Sort, input.Desc)
+
+	size := uint64(0)
+	if input.Offset < uint64(len(validators)) {
+		size = uint64(len(validators)) - input.Offset
+	}
+	if size > input.Limit {
+		size = input.Limit
+	}
+
+	result := make([]Validator, 0, size)
+	for index := input.Offset; index < uint64(len(validators)) && uint64(len(result)) < input.Limit; index++ {
+		result = append(result, s.newValidator(validators[index], snapshot, exponent))
+	}
+
+	return PagedValidatorsResponse{
+		Limit:  input.Limit,
+		Offset: input.Offset,
+		Total:  len(validators),
+		Data:   result,
+	}, nil
+}
+
+// sortValidators sorts by voting power and uptime descending and by other fields ascending by default, desc flag reverses the order.
+func sortValidators(validators []cosmos.ValidatorInfo, field string, desc bool) {
+	less := func(first cosmos.ValidatorInfo, second cosmos.ValidatorInfo) bool {
+		return first.Validator.Tokens.GT(second.Validator.Tokens)
+	}
+	switch field {
+	case ValidatorSortCommission:
+		less = func(first cosmos.ValidatorInfo, second cosmos.ValidatorInfo) bool {
+			return first.Validator.Commission.Rate.LT(second.Validator.Commission.Rate)
+		}
+	case ValidatorSortName:
+		less = func(first cosmos.ValidatorInfo, second cosmos.ValidatorInfo) bool {
+			return strings.ToLower(first.Validator.Description.Moniker) < strings.ToLower(second.Validator.Description.Moniker)
+		}
+	case ValidatorSortUptime:
+		// validators without signing info are placed after the ones with it
+		less = func(first cosmos.ValidatorInfo, second cosmos.ValidatorInfo) bool {
+			if first.SigningInfo == nil || second.SigningInfo == nil {
+				return first.SigningInfo != nil && second.SigningInfo == nil
+			}
+			return first.SigningInfo.MissedBlocksCounter < second.SigningInfo.MissedBlocksCounter
+		}
+	}
+
+	sort.SliceStable(validators, func(i, j int) bool {
+		if desc {
+			return less(validators[j], validators[i])
+		}
+		return less(validators[i], validators[j])
+	})
+}
+
+type ValidatorInput struct {
+	ChainID string
+	Address string
+	// Delegator is an address, which delegation to the validator is returned.
+	Delegator string
+}
+
+func (input ValidatorInput) Validate() error {
+	var errs []string
+	if input.ChainID == "" {
+		errs = append(errs, "invalid chainId")
+	}
+
+	if input.Address == "" {
+		errs = append(errs, "invalid address")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+type ValidatorDetails struct {
+	Validator
+	MaxCommission        string    `json:"maxCommission"`
+	MaxChangeRate        string    `json:"maxChangeRate"`
+	CommissionUpdateTime time.Time `json:"commissionUpdateTime"`
+	MinSelfDelegation    string    `json:"minSelfDelegation"`
+	SelfDelegation       string    `json:"selfDelegation"`
+	UnbondingHeight      int64     `json:"unbondingHeight"`
+	UnbondingTime        time.Time `json:"unbondingTime"`
+	// Delegation is an amount delegated by the delegator from request.
+	Delegation string `json:"delegation,omitempty"`
+}
+
+var ErrValidatorNotFound = errors.New("validator not found")
+
+func (s *Service) GetValidator(ctx context.Context, input ValidatorInput) (ValidatorDetails, error) {
+	chainData, err := s.repository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return ValidatorDetails{}, err
+	}
+
+	_, exponent, err := GetBaseDenom(chainData.Asset.Base, chainData.Asset.Display, chainData.Asset.DenomUnits)
+	if err != nil {
+		return ValidatorDetails{}, err
+	}
+
+	snapshot, err := s.getValidators(ctx, input.ChainID)
+	if err != nil {
+		return ValidatorDetails{}, err
+	}
+
+	var info *cosmos.ValidatorInfo
+	for index := range snapshot.validators {
+		if snapshot.validators[index].Validator.OperatorAddress == input.Address {
+			info = &snapshot.validators[index]
+			break
+		}
+	}
+	if info == nil {
+		return ValidatorDetails{}, fmt.Errorf("%w: %s", ErrValidatorNotFound, input.Address)
+	}
+
+	validator := &info.Validator
+	result := ValidatorDetails{
+		Validator:            s.newValidator(*info, snapshot, exponent),
+		MaxCommission:        formatPercent(validator.Commission.MaxRate, 1),
+		MaxChangeRate:        formatPercent(validator.Commission.MaxChangeRate, 1),
+		CommissionUpdateTime: validator.Commission.UpdateTime,
+		MinSelfDelegation:    FromBaseToDisplay(validator.MinSelfDelegation.String(), exponent),
+		UnbondingHeight:      validator.UnbondingHeight,
+		UnbondingTime:        validator.UnbondingTime,
+	}
+
+	_, operatorBytes, err := s.cosmosClient.ParseAddress(validator.OperatorAddress)
+	if err != nil {
+		return ValidatorDetails{}, err
+	}
+
+	selfAddress, err := s.cosmosClient.ConvertAddressPrefix(chainData.Prefix, operatorBytes)
+	if err != nil {
+		return ValidatorDetails{}, err
+	}
+
+	if result.SelfDelegation, err = s.getDelegation(ctx, input.ChainID, selfAddress, *validator, exponent); err != nil {
+		return ValidatorDetails{}, err
+	}
+
+	if input.Delegator != "" {
+		if result.Delegation, err = s.getDelegation(ctx, input.ChainID, input.Delegator, *validator, exponent); err != nil {
+			return ValidatorDetails{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// getDelegation returns display amount of delegation, zero is returned if there is no delegation.
+func (s *Service) getDelegation(ctx context.Context, chainID string, delegator string, validator staking.Validator, exponent int) (string, error) {
+	response, err := staking.NewQueryClient(s.cosmosClient.GetChainGrpcClient(chainID)).Delegation(ctx, &staking.QueryDelegationRequest{
+		DelegatorAddr: delegator,
+		ValidatorAddr: validator.OperatorAddress,
+	})
+	if cosmos.IsNotFound(err) {
+		return "0", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if response.DelegationResponse == nil {
+		return "0", nil
+	}
+	return FromBaseToDisplay(response.DelegationResponse.Balance.Amount.String(), exponent), nil
+}
@@ -0,0 +1,297 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Mobile-Web3/backend/internal/notify"
+	"github.com/Mobile-Web3/backend/pkg/log"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxAttempts is a count of delivery attempts before message is moved to dead letters.
+	maxAttempts = 6
+	// initialBackoff is a delay before the first retry, every next delay is doubled.
+	initialBackoff = time.Second * 2
+	// maxBackoff limits a delay between retries.
+	maxBackoff = time.Minute * 5
+	// attemptTimeout limits a single delivery attempt.
+	attemptTimeout = time.Second * 30
+	// pollInterval is a period of checking for deliveries, which retry time has come.
+	pollInterval = time.Second
+	// deliveryParallelism is a count of concurrent delivery attempts.
+	deliveryParallelism = 16
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRetrying  Status = "retrying"
+	StatusDelivered Status = "delivered"
+	StatusFailed    Status = "failed"
+)
+
+// Delivery is a notification sent to the target with its delivery state.
+type Delivery struct {
+	ID            string         `json:"id"`
+	TxHash        string         `json:"txHash,omitempty"`
+	Target        notify.Target  `json:"target"`
+	Message       notify.Message `json:"message"`
+	Status        Status         `json:"status"`
+	Attempts      int            `json:"attempts"`
+	LastError     string         `json:"lastError,omitempty"`
+	NextAttemptAt *time.Time     `json:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+type Repository interface {
+	Save(ctx context.Context, delivery Delivery) error
+	GetByTxHash(ctx context.Context, txHash string) ([]Delivery, error)
+	// GetDue returns pending and retrying deliveries, which next attempt time has come.
+	GetDue(ctx context.Context, now time.Time) ([]Delivery, error)
+}
+
+// DeadLetterRepository keeps deliveries, which have permanently failed.
+type DeadLetterRepository interface {
+	Add(ctx context.Context, delivery Delivery) error
+	GetAll(ctx context.Context) ([]Delivery, error)
+}
+
+// InvalidTargetHandler is called when the target will never accept notifications, so it could be pruned.
+type InvalidTargetHandler func(ctx context.Context, target notify.Target) error
+
+// Service delivers notifications in background with retries and exponential backoff.
+// Deliveries with their next attempt time are kept in the repository, so queued retries survive restart.
+// It implements notify.Notifier, so it is used in place of the channel notifiers.
+type Service struct {
+	logger      log.Logger
+	notifier    notify.Notifier
+	repository  Repository
+	deadLetters DeadLetterRepository
+
+	mutex                 sync.RWMutex
+	invalidTargetHandlers []InvalidTargetHandler
+	// inFlight are ids of deliveries, which attempts are running.
+	inFlight  map[string]struct{}
+	semaphore chan struct{}
+	wake      chan struct{}
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+func NewService(logger log.Logger, notifier notify.Notifier, repository Repository, deadLetters DeadLetterRepository) *Service {
+	return &Service{
+		logger:      logger,
+		notifier:    notifier,
+		repository:  repository,
+		deadLetters: deadLetters,
+		inFlight:    make(map[string]struct{}),
+		semaphore:   make(chan struct{}, deliveryParallelism),
+		wake:        make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+	}
+}
+
+// Start sends queued deliveries including ones left unfinished after restart.
+func (s *Service) Start() {
+	go s.run()
+}
+
+func (s *Service) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+}
+
+func (s *Service) OnInvalidTarget(handler InvalidTargetHandler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.invalidTargetHandlers = append(s.invalidTargetHandlers, handler)
+}
+
+// Notify registers the delivery and sends it in background, so the error is returned only if it is not registered.
+func (s *Service) Notify(ctx context.Context, target notify.Target, message notify.Message) error {
+	now := time.Now().UTC()
+	delivery := Delivery{
+		ID:            uuid.New().String(),
+		TxHash:        message.Data["txHash"],
+		Target:        target,
+		Message:       message,
+		Status:        StatusPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.repository.Save(ctx, delivery); err != nil {
+		return err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *Service) run() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+
+		s.deliverDue()
+	}
+}
+
+// deliverDue starts attempts of due deliveries. Deliveries are dispatched by the single run loop,
+// so delivery, which is not in flight before reading, can't be changed by running attempt.
+func (s *Service) deliverDue() {
+	s.mutex.RLock()
+	busy := make(map[string]struct{}, len(s.inFlight))
+	for id := range s.inFlight {
+		busy[id] = struct{}{}
+	}
+	s.mutex.RUnlock()
+
+	deliveries, err := s.repository.GetDue(context.Background(), time.Now().UTC())
+	if err != nil {
+		s.logger.Error(err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		if _, ok := busy[delivery.ID]; ok {
+			continue
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case s.semaphore <- struct{}{}:
+		}
+
+		s.mutex.Lock()
+		s.inFlight[delivery.ID] = struct{}{}
+		s.mutex.Unlock()
+
+		go func(delivery Delivery) {
+			defer func() {
+				s.mutex.Lock()
+				delete(s.inFlight, delivery.ID)
+				s.mutex.Unlock()
+				<-s.semaphore
+			}()
+			s.attempt(delivery)
+		}(delivery)
+	}
+}
+
+func backoff(attempt int) time.Duration {
+	delay := initialBackoff
+	for i := 1; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
+
+// attempt sends the delivery once and schedules the next attempt in the repository, if it has failed.
+func (s *Service) attempt(delivery Delivery) {
+	delivery.Attempts++
+	ctx, cancel := context.WithTimeout(context.Background(), attemptTimeout)
+	err := s.notifier.Notify(ctx, delivery.Target, delivery.Message)
+	cancel()
+
+	delivery.UpdatedAt = time.Now().UTC()
+	delivery.NextAttemptAt = nil
+	if err == nil {
+		delivery.Status = StatusDelivered
+		delivery.LastError = ""
+		s.save(delivery)
+		return
+	}
+
+	delivery.LastError = err.Error()
+	permanent := errors.Is(err, notify.ErrInvalidTarget) || errors.Is(err, notify.ErrRejected) || errors.Is(err, notify.ErrUnknownChannel)
+	if permanent || delivery.Attempts >= maxAttempts {
+		delivery.Status = StatusFailed
+		s.save(delivery)
+		if deadErr := s.deadLetters.Add(context.Background(), delivery); deadErr != nil {
+			s.logger.Error(deadErr)
+		}
+
+		if errors.Is(err, notify.ErrInvalidTarget) {
+			s.pruneTarget(delivery.Target)
+		}
+		return
+	}
+
+	nextAttemptAt := delivery.UpdatedAt.Add(backoff(delivery.Attempts))
+	delivery.Status = StatusRetrying
+	delivery.NextAttemptAt = &nextAttemptAt
+	s.save(delivery)
+}
+
+func (s *Service) save(delivery Delivery) {
+	if err := s.repository.Save(context.Background(), delivery); err != nil {
+		s.logger.Error(err)
+	}
+}
+
+func (s *Service) pruneTarget(target notify.Target) {
+	s.mutex.RLock()
+	handlers := s.invalidTargetHandlers
+	s.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(context.Background(), target); err != nil {
+			s.logger.Error(err)
+		}
+	}
+}
+
+type DeliveriesInput struct {
+	TxHash string `json:"txHash"`
+}
+
+func (input DeliveriesInput) Validate() error {
+	if input.TxHash == "" {
+		return errors.New("invalid txHash")
+	}
+
+	return nil
+}
+
+// withoutSecrets hides secrets of delivery targets, they are known to the registrants only.
+func withoutSecrets(deliveries []Delivery, err error) ([]Delivery, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range deliveries {
+		deliveries[index].Target = deliveries[index].Target.Public()
+	}
+	return deliveries, nil
+}
+
+func (s *Service) GetDeliveries(ctx context.Context, input DeliveriesInput) ([]Delivery, error) {
+	return withoutSecrets(s.repository.GetByTxHash(ctx, input.TxHash))
+}
+
+func (s *Service) GetDeadLetters(ctx context.Context) ([]Delivery, error) {
+	return withoutSecrets(s.deadLetters.GetAll(ctx))
+}
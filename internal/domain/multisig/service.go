@@ -0,0 +1,419 @@
+package multisig
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Mobile-Web3/backend/internal/domain/account"
+	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/pkg/cosmos"
+	"github.com/Mobile-Web3/backend/pkg/log"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	"github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+type Service struct {
+	logger          log.Logger
+	chainRepository chain.Repository
+	cosmosClient    *cosmos.Client
+}
+
+func NewService(logger log.Logger, chainRepository chain.Repository, cosmosClient *cosmos.Client) *Service {
+	return &Service{
+		logger:          logger,
+		chainRepository: chainRepository,
+		cosmosClient:    cosmosClient,
+	}
+}
+
+func formatErrors(errs []string) error {
+	result := errs[0]
+	for i := 1; i < len(errs); i++ {
+		result = fmt.Sprintf("%s; %s", result, errs[i])
+	}
+	return errors.New(result)
+}
+
+type Key struct {
+	Threshold uint32           `json:"threshold"`
+	PubKeys   []account.PubKey `json:"pubKeys"`
+}
+
+func (key Key) validate() []string {
+	var errs []string
+	if len(key.PubKeys) < 2 {
+		errs = append(errs, "at least two public keys are needed")
+	}
+
+	if key.Threshold == 0 || int(key.Threshold) > len(key.PubKeys) {
+		errs = append(errs, fmt.Sprintf("invalid threshold %d for %d public keys", key.Threshold, len(key.PubKeys)))
+	}
+
+	return errs
+}
+
+func (s *Service) parsePubKey(pubKey account.PubKey) (types.PubKey, error) {
+	pubKeyJSON, err := json.Marshal(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.cosmosClient.UnmarshalPubKeyJSON(pubKeyJSON)
+}
+
+func (s *Service) formatPubKey(pubKey types.PubKey) (account.PubKey, error) {
+	pubKeyJSON, err := s.cosmosClient.MarshalPubKeyJSON(pubKey)
+	if err != nil {
+		return account.PubKey{}, err
+	}
+
+	var result account.PubKey
+	if err = json.Unmarshal(pubKeyJSON, &result); err != nil {
+		return account.PubKey{}, err
+	}
+
+	return result, nil
+}
+
+func (s *Service) getMultisigPubKey(key Key) (*kmultisig.LegacyAminoPubKey, error) {
+	pubKeys := make([]types.PubKey, len(key.PubKeys))
+	for index, pubKey := range key.PubKeys {
+		parsed, err := s.parsePubKey(pubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		pubKeys[index] = parsed
+	}
+
+	return s.cosmosClient.CreateMultisigPubKey(int(key.Threshold), pubKeys)
+}
+
+type CreateAccountInput struct {
+	Key
+	ChainPrefixes []string `json:"chainPrefixes"`
+}
+
+func (input CreateAccountInput) Validate() error {
+	errs := input.Key.validate()
+	if len(input.ChainPrefixes) == 0 {
+		errs = append(errs, "at least one chain is needed")
+	}
+
+	if len(errs) > 0 {
+		return formatErrors(errs)
+	}
+
+	return nil
+}
+
+type AccountResponse struct {
+	Threshold uint32           `json:"threshold"`
+	PubKeys   []account.PubKey `json:"pubKeys"`
+	Addresses []string         `json:"addresses"`
+}
+
+func (s *Service) CreateAccount(ctx context.Context, input CreateAccountInput) (AccountResponse, error) {
+	multisigPubKey, err := s.getMultisigPubKey(input.Key)
+	if err != nil {
+		return AccountResponse{}, err
+	}
+
+	addresses := make([]string, len(input.ChainPrefixes))
+	for index, prefix := range input.ChainPrefixes {
+		address, convertErr := s.cosmosClient.ConvertAddressPrefix(prefix, multisigPubKey.Address())
+		if convertErr != nil {
+			return AccountResponse{}, convertErr
+		}
+
+		addresses[index] = address
+	}
+
+	return AccountResponse{
+		Threshold: input.Threshold,
+		PubKeys:   input.PubKeys,
+		Addresses: addresses,
+	}, nil
+}
+
+type CreateTxInput struct {
+	Key
+	ChainID     string `json:"chainId"`
+	To          string `json:"to"`
+	Amount      string `json:"amount"`
+	Memo        string `json:"memo"`
+	GasAdjusted string `json:"gasAdjusted"`
+	GasPrice    string `json:"gasPrice"`
+}
+
+func (input CreateTxInput) Validate() error {
+	errs := input.Key.validate()
+	if input.ChainID == "" {
+		errs = append(errs, "invalid chainId")
+	}
+
+	if input.To == "" {
+		errs = append(errs, "invalid to address")
+	}
+
+	if _, err := strconv.ParseFloat(input.Amount, 64); err != nil {
+		errs = append(errs, "invalid amount")
+	}
+
+	if _, err := strconv.ParseFloat(input.GasAdjusted, 64); err != nil {
+		errs = append(errs, "invalid gasAdjusted")
+	}
+
+	if _, err := strconv.ParseFloat(input.GasPrice, 64); err != nil {
+		errs = append(errs, "invalid gasPrice")
+	}
+
+	if len(errs) > 0 {
+		return formatErrors(errs)
+	}
+
+	return nil
+}
+
+type CreateTxResponse struct {
+	From string `json:"from"`
+	Tx   string `json:"tx"`
+}
+
+func (s *Service) CreateTransaction(ctx context.Context, input CreateTxInput) (CreateTxResponse, error) {
+	fromChain, err := s.chainRepository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return CreateTxResponse{}, err
+	}
+
+	multisigPubKey, err := s.getMultisigPubKey(input.Key)
+	if err != nil {
+		return CreateTxResponse{}, err
+	}
+
+	from, err := s.cosmosClient.ConvertAddressPrefix(fromChain.Prefix, multisigPubKey.Address())
+	if err != nil {
+		return CreateTxResponse{}, err
+	}
+
+	denom, exponent, err := chain.GetBaseDenom(fromChain.Asset.Base, fromChain.Asset.Display, fromChain.Asset.DenomUnits)
+	if err != nil {
+		err = fmt.Errorf("chain: %s; %s", fromChain.Name, err.Error())
+		s.logger.Error(err)
+		return CreateTxResponse{}, err
+	}
+
+	amount, err := chain.FromDisplayToBase(input.Amount, denom, exponent)
+	if err != nil {
+		err = fmt.Errorf("denom converting; chain: %s; amount: %s; denom: %s; %s", fromChain.Name, input.Amount, denom, err.Error())
+		s.logger.Error(err)
+		return CreateTxResponse{}, err
+	}
+
+	gasPrice, err := chain.FromDisplayToBase(input.GasPrice, denom, exponent)
+	if err != nil {
+		err = fmt.Errorf("denom converting; chain: %s; amount: %s; denom: %s; %s", fromChain.Name, input.GasPrice, denom, err.Error())
+		s.logger.Error(err)
+		return CreateTxResponse{}, err
+	}
+
+	coins, err := sdk.ParseCoinNormalized(amount)
+	if err != nil {
+		s.logger.Error(err)
+		return CreateTxResponse{}, err
+	}
+
+	msgSend := &bank.MsgSend{
+		FromAddress: from,
+		ToAddress:   input.To,
+		Amount:      sdk.Coins{coins},
+	}
+
+	txBytes, err := s.cosmosClient.CreateUnsignedTransaction(cosmos.UnsignedTransactionData{
+		ChainID:     fromChain.ID,
+		Memo:        input.Memo,
+		GasAdjusted: input.GasAdjusted,
+		GasPrice:    gasPrice,
+		Message:     msgSend,
+	})
+	if err != nil {
+		return CreateTxResponse{}, err
+	}
+
+	return CreateTxResponse{
+		From: from,
+		Tx:   string(txBytes),
+	}, nil
+}
+
+type SignTxInput struct {
+	Key
+	ChainID    string `json:"chainId"`
+	PrivateKey string `json:"privateKey"`
+	Tx         string `json:"tx"`
+}
+
+func (input SignTxInput) Validate() error {
+	errs := input.Key.validate()
+	if input.ChainID == "" {
+		errs = append(errs, "invalid chainId")
+	}
+
+	if input.PrivateKey == "" {
+		errs = append(errs, "invalid privateKey")
+	}
+
+	if input.Tx == "" {
+		errs = append(errs, "invalid tx")
+	}
+
+	if len(errs) > 0 {
+		return formatErrors(errs)
+	}
+
+	return nil
+}
+
+type Signature struct {
+	PubKey    account.PubKey `json:"pubKey"`
+	Signature string         `json:"signature"`
+}
+
+func (s *Service) SignTransaction(ctx context.Context, input SignTxInput) (Signature, error) {
+	chainData, err := s.chainRepository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	multisigPubKey, err := s.getMultisigPubKey(input.Key)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	pubKey, signature, err := s.cosmosClient.SignMultisigTransaction(ctx, cosmos.MultisigSignData{
+		ChainID:        chainData.ID,
+		ChainPrefix:    chainData.Prefix,
+		Key:            input.PrivateKey,
+		MultisigPubKey: multisigPubKey,
+		Tx:             []byte(input.Tx),
+	})
+	if err != nil {
+		s.logger.Error(err)
+		return Signature{}, err
+	}
+
+	formattedPubKey, err := s.formatPubKey(pubKey)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	return Signature{
+		PubKey:    formattedPubKey,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+type CombineTxInput struct {
+	Key
+	ChainID    string      `json:"chainId"`
+	Tx         string      `json:"tx"`
+	Signatures []Signature `json:"signatures"`
+	Broadcast  bool        `json:"broadcast"`
+}
+
+func (input CombineTxInput) Validate() error {
+	errs := input.Key.validate()
+	if input.ChainID == "" {
+		errs = append(errs, "invalid chainId")
+	}
+
+	if input.Tx == "" {
+		errs = append(errs, "invalid tx")
+	}
+
+	if len(input.Signatures) < int(input.Threshold) {
+		errs = append(errs, fmt.Sprintf("at least %d signatures are needed", input.Threshold))
+	}
+
+	if len(errs) > 0 {
+		return formatErrors(errs)
+	}
+
+	return nil
+}
+
+type CombineTxResponse struct {
+	TxBytes string `json:"txBytes"`
+	TxHash  string `json:"txHash"`
+}
+
+func (s *Service) CombineTransaction(ctx context.Context, input CombineTxInput) (CombineTxResponse, error) {
+	chainData, err := s.chainRepository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return CombineTxResponse{}, err
+	}
+
+	multisigPubKey, err := s.getMultisigPubKey(input.Key)
+	if err != nil {
+		return CombineTxResponse{}, err
+	}
+
+	signatures := make([]cosmos.PartialSignature, len(input.Signatures))
+	for index, signature := range input.Signatures {
+		pubKey, parseErr := s.parsePubKey(signature.PubKey)
+		if parseErr != nil {
+			return CombineTxResponse{}, parseErr
+		}
+
+		signatureBytes, decodeErr := base64.StdEncoding.DecodeString(signature.Signature)
+		if decodeErr != nil {
+			return CombineTxResponse{}, decodeErr
+		}
+
+		signatures[index] = cosmos.PartialSignature{
+			PubKey:    pubKey,
+			Signature: signatureBytes,
+		}
+	}
+
+	txBytes, err := s.cosmosClient.CombineMultisigTransaction(ctx, cosmos.MultisigCombineData{
+		ChainID:        chainData.ID,
+		ChainPrefix:    chainData.Prefix,
+		MultisigPubKey: multisigPubKey,
+		Tx:             []byte(input.Tx),
+		Signatures:     signatures,
+	})
+	if err != nil {
+		s.logger.Error(err)
+		return CombineTxResponse{}, err
+	}
+
+	response := CombineTxResponse{
+		TxBytes: base64.StdEncoding.EncodeToString(txBytes),
+	}
+	if !input.Broadcast {
+		return response, nil
+	}
+
+	rpcClient := s.cosmosClient.GetChainHttpClient(chainData.ID)
+	result, err := rpcClient.BroadcastTxSync(ctx, txBytes)
+	if err != nil {
+		s.logger.Error(err)
+		return CombineTxResponse{}, err
+	}
+
+	if result.Code != 0 {
+		err = fmt.Errorf("tx failed with code: %d; %s", result.Code, result.Log)
+		s.logger.Error(err)
+		return CombineTxResponse{}, err
+	}
+
+	response.TxHash = result.Hash.String()
+	return response, nil
+}
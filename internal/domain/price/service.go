@@ -0,0 +1,161 @@
+package price
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"golang.org/x/sync/singleflight"
+)
+
+// Provider returns fiat prices of assets keyed by asset id (coingecko id or lowercase symbol).
+type Provider interface {
+	GetPrices(ctx context.Context, ids []string, currency string) (map[string]float64, error)
+}
+
+type cachedPrice struct {
+	value     float64
+	updatedAt time.Time
+}
+
+type Service struct {
+	provider        Provider
+	ttl             time.Duration
+	defaultCurrency string
+	mutex           sync.RWMutex
+	cache           map[string]cachedPrice
+	// requests joins concurrent provider requests of the same prices on cache miss.
+	requests singleflight.Group
+}
+
+func NewService(provider Provider, ttl time.Duration, defaultCurrency string) *Service {
+	return &Service{
+		provider:        provider,
+		ttl:             ttl,
+		defaultCurrency: strings.ToLower(defaultCurrency),
+		mutex:           sync.RWMutex{},
+		cache:           make(map[string]cachedPrice),
+	}
+}
+
+// AssetID returns key of asset for price providers.
+func AssetID(asset chain.Asset) string {
+	if asset.CoingeckoID != "" {
+		return asset.CoingeckoID
+	}
+
+	return strings.ToLower(asset.Symbol)
+}
+
+// Currency returns requested currency or default one if empty.
+func (s *Service) Currency(currency string) string {
+	if currency == "" {
+		return s.defaultCurrency
+	}
+
+	return strings.ToLower(currency)
+}
+
+func cacheKey(id string, currency string) string {
+	return fmt.Sprintf("%s-%s", id, currency)
+}
+
+// GetPrices returns cached prices and requests expired ones from provider.
+// Stale prices are returned if provider is unavailable.
+func (s *Service) GetPrices(ctx context.Context, ids []string, currency string) (map[string]float64, error) {
+	currency = s.Currency(currency)
+	result := make(map[string]float64)
+	var expired []string
+
+	s.mutex.RLock()
+	for _, id := range ids {
+		price, ok := s.cache[cacheKey(id, currency)]
+		if ok {
+			result[id] = price.value
+		}
+		if !ok || time.Since(price.updatedAt) > s.ttl {
+			expired = append(expired, id)
+		}
+	}
+	s.mutex.RUnlock()
+
+	if len(expired) == 0 {
+		return result, nil
+	}
+
+	prices, err := s.requestPrices(ctx, expired, currency)
+	if err != nil {
+		if len(result) > 0 {
+			return result, nil
+		}
+		return nil, err
+	}
+
+	for id, value := range prices {
+		result[id] = value
+	}
+
+	return result, nil
+}
+
+// requestPrices requests prices from provider and caches them,
+// concurrent callers missing the same prices share the single request.
+func (s *Service) requestPrices(ctx context.Context, ids []string, currency string) (map[string]float64, error) {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	key := cacheKey(strings.Join(sorted, ","), currency)
+
+	value, err, _ := s.requests.Do(key, func() (interface{}, error) {
+		prices, err := s.provider.GetPrices(ctx, sorted, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		s.mutex.Lock()
+		for id, value := range prices {
+			s.cache[cacheKey(id, currency)] = cachedPrice{
+				value:     value,
+				updatedAt: now,
+			}
+		}
+		s.mutex.Unlock()
+		return prices, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(map[string]float64), nil
+}
+
+// GetPrice returns fiat price of chain asset.
+func (s *Service) GetPrice(ctx context.Context, asset chain.Asset, currency string) (float64, error) {
+	id := AssetID(asset)
+	prices, err := s.GetPrices(ctx, []string{id}, currency)
+	if err != nil {
+		return 0, err
+	}
+
+	price, ok := prices[id]
+	if !ok {
+		return 0, fmt.Errorf("price of %s not found", id)
+	}
+
+	return price, nil
+}
+
+// FiatValue converts display amount to fiat amount.
+func FiatValue(amount string, price float64) string {
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%.2f", value*price)
+}
@@ -0,0 +1,166 @@
+package restake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Mobile-Web3/backend/pkg/cosmos/connection"
+)
+
+// txTrackingTimeout limits waiting for grant and revoke txs to be included into block.
+const txTrackingTimeout = time.Minute * 5
+
+type GrantStatus string
+
+const (
+	// GrantStatusPending means that grant tx is broadcast, but not included into block yet.
+	GrantStatusPending GrantStatus = "pending"
+	GrantStatusActive  GrantStatus = "active"
+	// GrantStatusRevoking means that revoke tx is broadcast, rewards are compounded until it is included into block.
+	GrantStatusRevoking GrantStatus = "revoking"
+)
+
+// active reports whether authorizations of the grant are committed, so its rewards could be compounded.
+func (g Grant) active() bool {
+	return g.Status == GrantStatusActive || g.Status == GrantStatusRevoking
+}
+
+func (s *Service) getGrant(ctx context.Context, chainID string, delegator string) (Grant, bool, error) {
+	grants, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return Grant{}, false, err
+	}
+
+	for _, grant := range grants {
+		if grant.ChainID == chainID && grant.Delegator == delegator {
+			return grant, true, nil
+		}
+	}
+
+	return Grant{}, false, nil
+}
+
+// trackGrant activates the grant, when its tx is included into block.
+func (s *Service) trackGrant(grant Grant) {
+	deadline := grant.CreatedAt.Add(txTrackingTimeout)
+	err := s.cosmosClient.TrackTx(context.Background(), grant.ChainID, grant.TxHash, deadline, func(event connection.TxEvent) {
+		s.finishGrant(grant, event.Status == connection.TxStatusSuccess)
+	})
+	if err != nil {
+		s.logger.Error(fmt.Errorf("tracking grant tx %s; %w", grant.TxHash, err))
+		s.finishGrant(grant, false)
+	}
+}
+
+// finishGrant activates committed grant, failed one is replaced by the previous grant of the delegator if it exists.
+func (s *Service) finishGrant(grant Grant, committed bool) {
+	ctx := context.Background()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, ok, err := s.getGrant(ctx, grant.ChainID, grant.Delegator)
+	if err != nil {
+		s.logger.Error(err)
+		return
+	}
+
+	// grant has been replaced by the next one while its tx was awaited
+	if !ok || current.Status != GrantStatusPending || current.TxHash != grant.TxHash {
+		return
+	}
+
+	switch {
+	case committed:
+		current.Status = GrantStatusActive
+		current.Previous = nil
+		err = s.repository.Save(ctx, current)
+	case current.Previous != nil:
+		err = s.repository.Save(ctx, *current.Previous)
+	default:
+		err = s.repository.Remove(ctx, current.ChainID, current.Delegator)
+	}
+
+	if err != nil {
+		s.logger.Error(err)
+	}
+}
+
+// removeRevokedGrant removes the grant revoked on chain, unless it has been replaced by the next grant.
+func (s *Service) removeRevokedGrant(ctx context.Context, grant Grant) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, ok, err := s.getGrant(ctx, grant.ChainID, grant.Delegator)
+	if err != nil || !ok || current.TxHash != grant.TxHash {
+		return err
+	}
+
+	return s.repository.Remove(ctx, grant.ChainID, grant.Delegator)
+}
+
+// trackRevoke removes the grant, when its revoke tx is included into block.
+func (s *Service) trackRevoke(grant Grant) {
+	deadline := time.Now().Add(txTrackingTimeout)
+	if grant.RevokedAt != nil {
+		deadline = grant.RevokedAt.Add(txTrackingTimeout)
+	}
+
+	err := s.cosmosClient.TrackTx(context.Background(), grant.ChainID, grant.RevokeTxHash, deadline, func(event connection.TxEvent) {
+		s.finishRevoke(grant, event.Status == connection.TxStatusSuccess)
+	})
+	if err != nil {
+		s.logger.Error(fmt.Errorf("tracking revoke tx %s; %w", grant.RevokeTxHash, err))
+		s.finishRevoke(grant, false)
+	}
+}
+
+// finishRevoke removes the grant with committed revoke, grant with failed revoke is active again.
+func (s *Service) finishRevoke(grant Grant, committed bool) {
+	ctx := context.Background()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, ok, err := s.getGrant(ctx, grant.ChainID, grant.Delegator)
+	if err != nil {
+		s.logger.Error(err)
+		return
+	}
+
+	if !ok || current.Status != GrantStatusRevoking || current.RevokeTxHash != grant.RevokeTxHash {
+		return
+	}
+
+	if committed {
+		err = s.repository.Remove(ctx, current.ChainID, current.Delegator)
+	} else {
+		current.Status = GrantStatusActive
+		current.RevokeTxHash = ""
+		current.RevokedAt = nil
+		err = s.repository.Save(ctx, current)
+	}
+
+	if err != nil {
+		s.logger.Error(err)
+	}
+}
+
+// ResumePendingGrants continues tracking of grant and revoke txs left after restart,
+// txs with expired deadline are checked once.
+func (s *Service) ResumePendingGrants(ctx context.Context) error {
+	grants, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, grant := range grants {
+		switch grant.Status {
+		case GrantStatusPending:
+			s.trackGrant(grant)
+		case GrantStatusRevoking:
+			s.trackRevoke(grant)
+		}
+	}
+
+	return nil
+}
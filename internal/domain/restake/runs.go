@@ -0,0 +1,428 @@
+package restake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/pkg/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	distribution "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/google/uuid"
+)
+
+const (
+	restakeParallelism = 8
+	restakeMemo        = "restake"
+	// restakeBatchSize is a max count of delegators compounded in one tx.
+	restakeBatchSize = 10
+	// maxBatchGas limits gas of batch tx well below block gas limit of cosmos chains.
+	maxBatchGas = 2000000
+	// maxBatchMessages limits size of batch tx.
+	maxBatchMessages = 100
+)
+
+var (
+	errGrantRevoked           = errors.New("grant is revoked or expired")
+	errWithdrawAddressChanged = errors.New("withdraw address differs from delegator address")
+	errRewardBelowFee         = errors.New("reward does not exceed fee of compounding")
+)
+
+type RunStatus string
+
+const (
+	// RunStatusExecuted means that tx with compounding is accepted by the chain.
+	RunStatusExecuted RunStatus = "executed"
+	// RunStatusSkipped means that rewards are smaller than the minimal reward or don't exceed fee of compounding.
+	RunStatusSkipped RunStatus = "skipped"
+	// RunStatusRevoked means that grant is not found in the chain, so the delegator is not compounded anymore.
+	RunStatusRevoked RunStatus = "revoked"
+	RunStatusFailed  RunStatus = "failed"
+)
+
+// Run is a result of compounding of the delegator rewards by the scheduled job.
+type Run struct {
+	ID        string    `json:"id"`
+	ChainID   string    `json:"chainId"`
+	Delegator string    `json:"delegator"`
+	Status    RunStatus `json:"status"`
+	Reward    string    `json:"reward"`
+	TxHash    string    `json:"txHash,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type RunRepository interface {
+	Add(ctx context.Context, runs ...Run) error
+	GetByDelegator(ctx context.Context, chainID string, delegator string) ([]Run, error)
+}
+
+type RunsInput struct {
+	ChainID string
+	Address string
+}
+
+func (input RunsInput) Validate() error {
+	if input.ChainID == "" {
+		return errors.New("invalid chainId")
+	}
+
+	if input.Address == "" {
+		return errors.New("invalid address")
+	}
+
+	return nil
+}
+
+func (s *Service) GetRuns(ctx context.Context, input RunsInput) ([]Run, error) {
+	return s.runRepository.GetByDelegator(ctx, input.ChainID, input.Address)
+}
+
+// Restake compounds rewards of all delegators, which granted authorizations to our bot.
+func (s *Service) Restake(ctx context.Context) error {
+	grants, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	chains := make(map[string][]Grant)
+	for _, grant := range grants {
+		// rewards are compounded by the previous grant, until the pending one is included into block
+		if grant.Status == GrantStatusPending && grant.Previous != nil {
+			grant = *grant.Previous
+		}
+
+		if grant.active() {
+			chains[grant.ChainID] = append(chains[grant.ChainID], grant)
+		}
+	}
+
+	semaphore := make(chan struct{}, restakeParallelism)
+	wg := &sync.WaitGroup{}
+	for chainID, grants := range chains {
+		wg.Add(1)
+		go func(chainID string, grants []Grant) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			s.restakeChain(ctx, chainID, grants)
+		}(chainID, grants)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// newMsgExec sets grantee as string, because sdk addresses are encoded with the cosmos prefix.
+func newMsgExec(grantee string, messages []sdk.Msg) *authz.MsgExec {
+	msg := authz.NewMsgExec(nil, messages)
+	msg.Grantee = grantee
+	return &msg
+}
+
+// compounding is the delegator messages, which are executed in the chain.
+type compounding struct {
+	run      int
+	messages []sdk.Msg
+	gas      uint64
+}
+
+// batchCompoundings groups compoundings into txs limited by count of delegators, messages and gas,
+// so tx doesn't exceed block gas limit and max tx size.
+func batchCompoundings(compoundings []compounding) [][]compounding {
+	var batches [][]compounding
+	var batch []compounding
+	gas, messages := uint64(0), 0
+	for _, item := range compoundings {
+		if len(batch) > 0 && (len(batch) >= restakeBatchSize ||
+			gas+item.gas > maxBatchGas || messages+len(item.messages) > maxBatchMessages) {
+			batches = append(batches, batch)
+			batch, gas, messages = nil, 0, 0
+		}
+
+		batch = append(batch, item)
+		gas += item.gas
+		messages += len(item.messages)
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// restakeChain compounds rewards of delegators of the chain in batches, txs are signed with sequential sequences
+// of our bot account. Delegators, which messages fail in simulation, are excluded from txs, and if a batch tx is rejected,
+// its delegators are compounded one by one.
+func (s *Service) restakeChain(ctx context.Context, chainID string, grants []Grant) {
+	chainData, err := s.chainRepository.GetByID(ctx, chainID)
+	if err != nil {
+		s.logger.Error(err)
+		return
+	}
+
+	denom, _, err := chain.GetBaseDenom(chainData.Asset.Base, chainData.Asset.Display, chainData.Asset.DenomUnits)
+	if err != nil {
+		s.logger.Error(err)
+		return
+	}
+
+	grantee, err := s.grantee(chainData.Prefix)
+	if err != nil {
+		s.logger.Error(err)
+		return
+	}
+
+	chainMinReward, err := s.minReward(chainData)
+	if err != nil {
+		s.logger.Error(err)
+		return
+	}
+
+	runs := make([]Run, 0, len(grants))
+	var compoundings []compounding
+	for _, grant := range grants {
+		run := Run{
+			ID:        uuid.NewString(),
+			ChainID:   chainID,
+			Delegator: grant.Delegator,
+			CreatedAt: time.Now(),
+		}
+
+		var gas uint64
+		delegatorMessages, reward, err := s.compoundMessages(ctx, chainID, denom, grantee, grant, chainMinReward)
+		if reward.IsPositive() {
+			run.Reward, _ = chain.FormatDisplayAmount(reward.String(), chainData.Asset)
+		}
+		if err == nil && len(delegatorMessages) > 0 {
+			gas, err = s.simulate(ctx, chainData, []sdk.Msg{newMsgExec(grantee, delegatorMessages)})
+			// fee of the delegator messages is paid by our bot, so compounding of smaller reward is unprofitable
+			if err == nil && reward.LTE(feeAmount(gas, chainData.AverageGasPrice)) {
+				delegatorMessages = nil
+				run.Error = errRewardBelowFee.Error()
+			}
+		}
+
+		switch {
+		case errors.Is(err, errGrantRevoked):
+			run.Status = RunStatusRevoked
+			if err = s.removeRevokedGrant(ctx, grant); err != nil {
+				s.logger.Error(err)
+			}
+		case err != nil:
+			run.Status = RunStatusFailed
+			run.Error = err.Error()
+		case len(delegatorMessages) == 0:
+			run.Status = RunStatusSkipped
+		default:
+			run.Status = RunStatusExecuted
+			compoundings = append(compoundings, compounding{
+				run:      len(runs),
+				messages: delegatorMessages,
+				gas:      gas,
+			})
+		}
+		runs = append(runs, run)
+	}
+
+	if len(compoundings) > 0 {
+		s.executeCompoundings(ctx, chainData, grantee, compoundings, runs)
+	}
+
+	if err = s.runRepository.Add(ctx, runs...); err != nil {
+		s.logger.Error(err)
+	}
+}
+
+// executeCompoundings executes batches and updates their runs. Sequence is increased only by accepted txs,
+// and if result of broadcasting is unknown, the rest batches are not executed, because sequence is unknown as well.
+func (s *Service) executeCompoundings(ctx context.Context, chainData chain.Chain, grantee string, compoundings []compounding, runs []Run) {
+	sequence, abortErr := s.cosmosClient.GetAccountSequence(ctx, chainData.ID, grantee)
+
+	execute := func(batch []compounding) error {
+		if abortErr != nil {
+			return abortErr
+		}
+
+		var messages []sdk.Msg
+		gas := uint64(0)
+		for _, item := range batch {
+			messages = append(messages, item.messages...)
+			gas += item.gas
+		}
+
+		txHash, err := s.execute(ctx, chainData, []sdk.Msg{newMsgExec(grantee, messages)}, gas, sequence)
+		if err != nil {
+			if !errors.Is(err, errTxFailed) {
+				abortErr = err
+			}
+			return err
+		}
+
+		sequence++
+		for _, item := range batch {
+			runs[item.run].TxHash = txHash
+		}
+		return nil
+	}
+
+	fail := func(item compounding, err error) {
+		runs[item.run].Status = RunStatusFailed
+		runs[item.run].Error = err.Error()
+	}
+
+	for _, batch := range batchCompoundings(compoundings) {
+		err := execute(batch)
+		if err == nil {
+			continue
+		}
+
+		s.logger.Error(err)
+		if len(batch) == 1 || !errors.Is(err, errTxFailed) {
+			for _, item := range batch {
+				fail(item, err)
+			}
+			continue
+		}
+
+		// the whole batch is rejected because of one delegator, e.g. whose grant is revoked after simulation
+		for _, item := range batch {
+			if err = execute([]compounding{item}); err != nil {
+				fail(item, err)
+			}
+		}
+	}
+}
+
+// feeAmount returns fee in base denom paid for the gas.
+func feeAmount(gas uint64, gasPrice float64) sdk.Int {
+	return sdk.NewInt(int64(math.Ceil(float64(gas) * gasPrice)))
+}
+
+// compoundMessages returns withdraw and delegate messages of the delegator rewards from granted validators,
+// no messages are returned if total reward is smaller than the minimal reward of the grant or of the chain.
+func (s *Service) compoundMessages(
+	ctx context.Context,
+	chainID string,
+	denom string,
+	grantee string,
+	grant Grant,
+	chainMinReward sdk.Int) ([]sdk.Msg, sdk.Int, error) {
+	total := sdk.ZeroInt()
+	for _, msgTypeURL := range []string{withdrawMsgTypeURL, delegateMsgTypeURL} {
+		granted, err := s.cosmosClient.HasGrant(ctx, chainID, grant.Delegator, grantee, msgTypeURL)
+		if err != nil {
+			return nil, total, err
+		}
+		if !granted {
+			return nil, total, errGrantRevoked
+		}
+	}
+
+	distributionClient := distribution.NewQueryClient(s.cosmosClient.GetChainGrpcClient(chainID))
+	// withdrawn rewards are delegated from delegator balance, so they must be withdrawn to it
+	withdrawAddress, err := distributionClient.DelegatorWithdrawAddress(ctx, &distribution.QueryDelegatorWithdrawAddressRequest{
+		DelegatorAddress: grant.Delegator,
+	})
+	if err != nil {
+		return nil, total, err
+	}
+	if withdrawAddress.WithdrawAddress != grant.Delegator {
+		return nil, total, errWithdrawAddressChanged
+	}
+
+	rewards, err := distributionClient.DelegationTotalRewards(ctx, &distribution.QueryDelegationTotalRewardsRequest{
+		DelegatorAddress: grant.Delegator,
+	})
+	if err != nil {
+		return nil, total, err
+	}
+
+	validators := make(map[string]struct{}, len(grant.Validators))
+	for _, validator := range grant.Validators {
+		validators[validator] = struct{}{}
+	}
+
+	var messages []sdk.Msg
+	for _, reward := range rewards.Rewards {
+		if _, ok := validators[reward.ValidatorAddress]; !ok {
+			continue
+		}
+
+		amount := reward.Reward.AmountOf(denom).TruncateInt()
+		if !amount.IsPositive() {
+			continue
+		}
+
+		total = total.Add(amount)
+		messages = append(messages,
+			&distribution.MsgWithdrawDelegatorReward{
+				DelegatorAddress: grant.Delegator,
+				ValidatorAddress: reward.ValidatorAddress,
+			},
+			&staking.MsgDelegate{
+				DelegatorAddress: grant.Delegator,
+				ValidatorAddress: reward.ValidatorAddress,
+				Amount:           sdk.NewCoin(denom, amount),
+			})
+	}
+
+	minReward, ok := sdk.NewIntFromString(grant.MinReward)
+	if !ok || minReward.LT(chainMinReward) {
+		minReward = chainMinReward
+	}
+	if total.LT(minReward) {
+		return nil, total, nil
+	}
+
+	return messages, total, nil
+}
+
+// simulate returns adjusted gas of the messages.
+func (s *Service) simulate(ctx context.Context, chainData chain.Chain, messages []sdk.Msg) (uint64, error) {
+	gasUsed, err := s.cosmosClient.SimulateGas(ctx, cosmos.SimulateTransactionData{
+		ChainID:     chainData.ID,
+		Memo:        restakeMemo,
+		ChainPrefix: chainData.Prefix,
+		Key:         s.granteeKey,
+		Messages:    messages,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(math.Round(float64(gasUsed) * s.gasAdjustment)), nil
+}
+
+// execute signs messages with the key of our bot and the sequence and broadcasts them, fees are paid by our bot
+// with average gas price. Messages are not simulated, because simulation fails with sequences of txs in mempool.
+func (s *Service) execute(ctx context.Context, chainData chain.Chain, messages []sdk.Msg, gas uint64, sequence uint64) (string, error) {
+	denom, _, err := chain.GetBaseDenom(chainData.Asset.Base, chainData.Asset.Display, chainData.Asset.DenomUnits)
+	if err != nil {
+		return "", err
+	}
+
+	fees := fmt.Sprintf("%s%s", feeAmount(gas, chainData.AverageGasPrice), denom)
+	txBytes, err := s.cosmosClient.CreateSignedTransaction(ctx, cosmos.SendTransactionData{
+		ChainID:     chainData.ID,
+		Memo:        restakeMemo,
+		GasAdjusted: strconv.FormatUint(gas, 10),
+		GasPrice:    fees,
+		ChainPrefix: chainData.Prefix,
+		Key:         s.granteeKey,
+		Messages:    messages,
+		Sequence:    &sequence,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.broadcast(ctx, chainData.ID, txBytes)
+}
@@ -0,0 +1,481 @@
+package restake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/pkg/cosmos"
+	"github.com/Mobile-Web3/backend/pkg/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	distribution "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+const defaultGrantDuration = time.Hour * 24 * 365
+
+// errTxFailed means that tx is rejected by the node, so sequence of the account is not used.
+var errTxFailed = errors.New("tx failed")
+
+var ErrGrantPending = errors.New("grant tx is not included into block yet")
+
+var (
+	withdrawMsgTypeURL = sdk.MsgTypeURL(&distribution.MsgWithdrawDelegatorReward{})
+	delegateMsgTypeURL = sdk.MsgTypeURL(&staking.MsgDelegate{})
+)
+
+// Grant is a consent of the delegator to compound rewards from the validators by our bot.
+// Rewards are compounded only after grant tx is included into block.
+type Grant struct {
+	ChainID    string   `json:"chainId"`
+	Delegator  string   `json:"delegator"`
+	Validators []string `json:"validators"`
+	// MinReward is a minimal total reward in base denom, smaller rewards are not compounded.
+	MinReward  string      `json:"minReward"`
+	TxHash     string      `json:"txHash"`
+	Status     GrantStatus `json:"status"`
+	Expiration time.Time   `json:"expiration"`
+	CreatedAt  time.Time   `json:"createdAt"`
+	// Previous is the active grant replaced by the pending one, it is restored if pending grant fails.
+	Previous     *Grant     `json:"previous,omitempty"`
+	RevokeTxHash string     `json:"revokeTxHash,omitempty"`
+	RevokedAt    *time.Time `json:"revokedAt,omitempty"`
+}
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]Grant, error)
+	// Save replaces grant of the same chain and delegator.
+	Save(ctx context.Context, grant Grant) error
+	Remove(ctx context.Context, chainID string, delegator string) error
+}
+
+// ParseMinRewards parses comma separated list of chainId:amount minimal rewards in display denom.
+func ParseMinRewards(value string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid min reward %s, expected chainId:amount", item)
+		}
+
+		if amount, err := strconv.ParseFloat(parts[1], 64); err != nil || amount < 0 {
+			return nil, fmt.Errorf("invalid min reward %s, expected chainId:amount", item)
+		}
+
+		result[parts[0]] = parts[1]
+	}
+
+	return result, nil
+}
+
+type Service struct {
+	gasAdjustment float64
+	// minRewards are minimal rewards of chains in display denom set by operator, they override smaller minimal rewards of grants.
+	minRewards      map[string]string
+	granteeKey      string
+	logger          log.Logger
+	chainRepository chain.Repository
+	repository      Repository
+	runRepository   RunRepository
+	cosmosClient    *cosmos.Client
+
+	// mutex guards status changes of grants.
+	mutex sync.Mutex
+}
+
+func NewService(
+	gasAdjustment float64,
+	minRewards map[string]string,
+	granteeKey string,
+	logger log.Logger,
+	chainRepository chain.Repository,
+	repository Repository,
+	runRepository RunRepository,
+	cosmosClient *cosmos.Client) *Service {
+	return &Service{
+		gasAdjustment:   gasAdjustment,
+		minRewards:      minRewards,
+		granteeKey:      granteeKey,
+		logger:          logger,
+		chainRepository: chainRepository,
+		repository:      repository,
+		runRepository:   runRepository,
+		cosmosClient:    cosmosClient,
+	}
+}
+
+// grantee returns address of our bot in the chain.
+func (s *Service) grantee(chainPrefix string) (string, error) {
+	key, err := s.cosmosClient.CreateAccountFromHexKey(s.granteeKey)
+	if err != nil {
+		return "", err
+	}
+
+	return s.cosmosClient.ConvertAddressPrefix(chainPrefix, key.PubKey().Address())
+}
+
+// sendTransaction signs messages with the key of user and broadcasts them.
+func (s *Service) sendTransaction(ctx context.Context, chainData chain.Chain, key string, gasAdjusted string, gasPrice string, messages []sdk.Msg) (string, error) {
+	denom, exponent, err := chain.GetBaseDenom(chainData.Asset.Base, chainData.Asset.Display, chainData.Asset.DenomUnits)
+	if err != nil {
+		return "", fmt.Errorf("chain: %s; %s", chainData.Name, err.Error())
+	}
+
+	fees, err := chain.FromDisplayToBase(gasPrice, denom, exponent)
+	if err != nil {
+		return "", fmt.Errorf("denom converting; chain: %s; amount: %s; denom: %s; %s", chainData.Name, gasPrice, denom, err.Error())
+	}
+
+	txBytes, err := s.cosmosClient.CreateSignedTransaction(ctx, cosmos.SendTransactionData{
+		ChainID:     chainData.ID,
+		GasAdjusted: gasAdjusted,
+		GasPrice:    fees,
+		ChainPrefix: chainData.Prefix,
+		Key:         key,
+		Messages:    messages,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.broadcast(ctx, chainData.ID, txBytes)
+}
+
+// minReward returns minimal reward of the chain in base denom set by operator, zero if it is not set.
+func (s *Service) minReward(chainData chain.Chain) (sdk.Int, error) {
+	amount, ok := s.minRewards[chainData.ID]
+	if !ok {
+		return sdk.ZeroInt(), nil
+	}
+
+	denom, exponent, err := chain.GetBaseDenom(chainData.Asset.Base, chainData.Asset.Display, chainData.Asset.DenomUnits)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	baseAmount, err := chain.FromDisplayToBase(amount, denom, exponent)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	coin, err := sdk.ParseCoinNormalized(baseAmount)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	return coin.Amount, nil
+}
+
+func (s *Service) broadcast(ctx context.Context, chainID string, txBytes []byte) (string, error) {
+	response, err := s.cosmosClient.GetChainHttpClient(chainID).BroadcastTxSync(ctx, txBytes)
+	if err != nil {
+		return "", err
+	}
+
+	if response.Code != 0 {
+		return "", fmt.Errorf("%w with code: %d; TxHash: %s; log: %s", errTxFailed, response.Code, response.Hash.String(), response.Log)
+	}
+
+	return response.Hash.String(), nil
+}
+
+type GrantInput struct {
+	ChainID string `json:"chainId"`
+	Address string `json:"address"`
+	Key     string `json:"key"`
+	// Validators are operator addresses, which rewards are compounded.
+	Validators []string `json:"validators"`
+	// MinReward is a minimal total reward in display denom, smaller rewards are not compounded.
+	MinReward string `json:"minReward"`
+	// Expiration of grants, one year from now by default.
+	Expiration  *time.Time `json:"expiration"`
+	GasAdjusted string     `json:"gasAdjusted"`
+	GasPrice    string     `json:"gasPrice"`
+}
+
+func (input GrantInput) Validate() error {
+	var errs []string
+	if input.ChainID == "" {
+		errs = append(errs, "invalid chainId")
+	}
+
+	if input.Address == "" {
+		errs = append(errs, "invalid address")
+	}
+
+	if input.Key == "" {
+		errs = append(errs, "invalid key")
+	}
+
+	if len(input.Validators) == 0 {
+		errs = append(errs, "empty validators")
+	}
+
+	if value, err := strconv.ParseFloat(input.MinReward, 64); err != nil || value <= 0 {
+		errs = append(errs, "invalid minReward")
+	}
+
+	if input.Expiration != nil && !input.Expiration.After(time.Now()) {
+		errs = append(errs, "invalid expiration")
+	}
+
+	if _, err := strconv.ParseFloat(input.GasAdjusted, 64); err != nil {
+		errs = append(errs, "invalid gasAdjusted")
+	}
+
+	if _, err := strconv.ParseFloat(input.GasPrice, 64); err != nil {
+		errs = append(errs, "invalid gasPrice")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+type GrantResponse struct {
+	TxHash     string      `json:"txHash"`
+	Grantee    string      `json:"grantee"`
+	Status     GrantStatus `json:"status"`
+	Expiration time.Time   `json:"expiration"`
+}
+
+// newMsgGrant sets addresses as strings, because sdk addresses are encoded with the cosmos prefix.
+func newMsgGrant(granter string, grantee string, authorization authz.Authorization, expiration time.Time) (*authz.MsgGrant, error) {
+	msg, err := authz.NewMsgGrant(nil, nil, authorization, &expiration)
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Granter = granter
+	msg.Grantee = grantee
+	return msg, nil
+}
+
+// Grant authorizes our bot to withdraw rewards of the delegator and delegate them to the validators.
+// Grant is pending until its tx is included into block, the previous grant of the delegator is compounded meanwhile.
+func (s *Service) Grant(ctx context.Context, input GrantInput) (GrantResponse, error) {
+	chainData, err := s.chainRepository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return GrantResponse{}, err
+	}
+
+	denom, exponent, err := chain.GetBaseDenom(chainData.Asset.Base, chainData.Asset.Display, chainData.Asset.DenomUnits)
+	if err != nil {
+		return GrantResponse{}, err
+	}
+
+	minReward, err := chain.FromDisplayToBase(input.MinReward, denom, exponent)
+	if err != nil {
+		return GrantResponse{}, err
+	}
+
+	minRewardCoin, err := sdk.ParseCoinNormalized(minReward)
+	if err != nil {
+		return GrantResponse{}, err
+	}
+
+	grantee, err := s.grantee(chainData.Prefix)
+	if err != nil {
+		s.logger.Error(err)
+		return GrantResponse{}, err
+	}
+
+	expiration := time.Now().Add(defaultGrantDuration)
+	if input.Expiration != nil {
+		expiration = *input.Expiration
+	}
+
+	withdrawGrant, err := newMsgGrant(input.Address, grantee, authz.NewGenericAuthorization(withdrawMsgTypeURL), expiration)
+	if err != nil {
+		return GrantResponse{}, err
+	}
+
+	delegateGrant, err := newMsgGrant(input.Address, grantee, &staking.StakeAuthorization{
+		Validators: &staking.StakeAuthorization_AllowList{
+			AllowList: &staking.StakeAuthorization_Validators{
+				Address: input.Validators,
+			},
+		},
+		AuthorizationType: staking.AuthorizationType_AUTHORIZATION_TYPE_DELEGATE,
+	}, expiration)
+	if err != nil {
+		return GrantResponse{}, err
+	}
+
+	txHash, err := s.sendTransaction(ctx, chainData, input.Key, input.GasAdjusted, input.GasPrice, []sdk.Msg{withdrawGrant, delegateGrant})
+	if err != nil {
+		s.logger.Error(err)
+		return GrantResponse{}, err
+	}
+
+	grant := Grant{
+		ChainID:    chainData.ID,
+		Delegator:  input.Address,
+		Validators: input.Validators,
+		MinReward:  minRewardCoin.Amount.String(),
+		TxHash:     txHash,
+		Status:     GrantStatusPending,
+		Expiration: expiration,
+		CreatedAt:  time.Now(),
+	}
+	if err = s.savePendingGrant(ctx, grant); err != nil {
+		s.logger.Error(err)
+		return GrantResponse{}, err
+	}
+	s.trackGrant(grant)
+
+	return GrantResponse{
+		TxHash:     txHash,
+		Grantee:    grantee,
+		Status:     grant.Status,
+		Expiration: expiration,
+	}, nil
+}
+
+// savePendingGrant saves the grant keeping the active grant of the delegator as the previous one.
+func (s *Service) savePendingGrant(ctx context.Context, grant Grant) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, ok, err := s.getGrant(ctx, grant.ChainID, grant.Delegator)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ok && current.active():
+		current.Status = GrantStatusActive
+		current.RevokeTxHash = ""
+		current.RevokedAt = nil
+		grant.Previous = &current
+	case ok:
+		grant.Previous = current.Previous
+	}
+
+	return s.repository.Save(ctx, grant)
+}
+
+type RevokeInput struct {
+	ChainID     string `json:"chainId"`
+	Address     string `json:"address"`
+	Key         string `json:"key"`
+	GasAdjusted string `json:"gasAdjusted"`
+	GasPrice    string `json:"gasPrice"`
+}
+
+func (input RevokeInput) Validate() error {
+	var errs []string
+	if input.ChainID == "" {
+		errs = append(errs, "invalid chainId")
+	}
+
+	if input.Address == "" {
+		errs = append(errs, "invalid address")
+	}
+
+	if input.Key == "" {
+		errs = append(errs, "invalid key")
+	}
+
+	if _, err := strconv.ParseFloat(input.GasAdjusted, 64); err != nil {
+		errs = append(errs, "invalid gasAdjusted")
+	}
+
+	if _, err := strconv.ParseFloat(input.GasPrice, 64); err != nil {
+		errs = append(errs, "invalid gasPrice")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+type RevokeResponse struct {
+	TxHash string `json:"txHash"`
+}
+
+// Revoke removes authorizations of our bot and stops compounding of the delegator rewards,
+// when revoke tx is included into block. Pending grant can't be revoked until it is included.
+func (s *Service) Revoke(ctx context.Context, input RevokeInput) (RevokeResponse, error) {
+	chainData, err := s.chainRepository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return RevokeResponse{}, err
+	}
+
+	grant, found, err := s.getGrant(ctx, chainData.ID, input.Address)
+	if err != nil {
+		s.logger.Error(err)
+		return RevokeResponse{}, err
+	}
+
+	if found && grant.Status == GrantStatusPending {
+		return RevokeResponse{}, ErrGrantPending
+	}
+
+	grantee, err := s.grantee(chainData.Prefix)
+	if err != nil {
+		s.logger.Error(err)
+		return RevokeResponse{}, err
+	}
+
+	txHash, err := s.sendTransaction(ctx, chainData, input.Key, input.GasAdjusted, input.GasPrice, []sdk.Msg{
+		&authz.MsgRevoke{
+			Granter:    input.Address,
+			Grantee:    grantee,
+			MsgTypeUrl: withdrawMsgTypeURL,
+		},
+		&authz.MsgRevoke{
+			Granter:    input.Address,
+			Grantee:    grantee,
+			MsgTypeUrl: delegateMsgTypeURL,
+		},
+	})
+	if err != nil {
+		s.logger.Error(err)
+		return RevokeResponse{}, err
+	}
+
+	// authorizations not known by our bot are revoked without tracking
+	if found {
+		if grant, err = s.saveRevokingGrant(ctx, chainData.ID, input.Address, txHash); err != nil {
+			s.logger.Error(err)
+			return RevokeResponse{}, err
+		}
+		s.trackRevoke(grant)
+	}
+
+	return RevokeResponse{
+		TxHash: txHash,
+	}, nil
+}
+
+// saveRevokingGrant marks the grant of the delegator as revoking by the tx.
+func (s *Service) saveRevokingGrant(ctx context.Context, chainID string, delegator string, txHash string) (Grant, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	grant, ok, err := s.getGrant(ctx, chainID, delegator)
+	if err != nil {
+		return Grant{}, err
+	}
+
+	if !ok {
+		return Grant{}, fmt.Errorf("grant of %s is not found", delegator)
+	}
+
+	now := time.Now()
+	grant.Status = GrantStatusRevoking
+	grant.RevokeTxHash = txHash
+	grant.RevokedAt = &now
+	return grant, s.repository.Save(ctx, grant)
+}
@@ -0,0 +1,155 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Mobile-Web3/backend/pkg/cosmos"
+	"github.com/Mobile-Web3/backend/pkg/cosmos/connection"
+)
+
+// mempoolPollInterval is a period of checking that tx has got into mempool.
+const mempoolPollInterval = time.Second * 2
+
+type TxStage string
+
+const (
+	TxStageBroadcast TxStage = "broadcast"
+	// TxStageMempool is sent only if the tx is found among the first 100 txs of node mempool,
+	// because tendermint neither pages unconfirmed txs nor finds them by hash. So the tx could be
+	// included into block without this stage.
+	TxStageMempool  TxStage = "mempool"
+	TxStageIncluded TxStage = "included"
+	TxStageSuccess  TxStage = "success"
+	TxStageFailure  TxStage = "failure"
+	TxStageTimeout  TxStage = "timeout"
+)
+
+type TxStatusEvent struct {
+	Stage     TxStage `json:"stage"`
+	TxHash    string  `json:"txHash"`
+	Height    int64   `json:"height,omitempty"`
+	Code      uint32  `json:"code,omitempty"`
+	Log       string  `json:"log,omitempty"`
+	Reason    string  `json:"reason,omitempty"`
+	GasUsed   int64   `json:"gasUsed,omitempty"`
+	GasWanted int64   `json:"gasWanted,omitempty"`
+}
+
+type TxEventsInput struct {
+	ChainID string `json:"chainId"`
+	TxHash  string `json:"txHash"`
+}
+
+func (input TxEventsInput) Validate() error {
+	var errs []string
+	if input.ChainID == "" {
+		errs = append(errs, "invalid chainId")
+	}
+
+	if !cosmos.IsValidTxHash(input.TxHash) {
+		errs = append(errs, "invalid txHash")
+	}
+
+	if len(errs) > 0 {
+		return formatErrors(errs)
+	}
+
+	return nil
+}
+
+// StreamTxEvents sends stages of the tx: broadcast, mempool, included with height, and then its result.
+// Channel is closed after the result or when ctx is done.
+func (s *Service) StreamTxEvents(ctx context.Context, input TxEventsInput) (<-chan TxStatusEvent, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	chainData, err := s.chainRepository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan connection.TxEvent, 1)
+	if err = s.cosmosClient.TrackTx(ctx, chainData.ID, input.TxHash, time.Now().Add(s.txTrackingTimeout), func(event connection.TxEvent) {
+		results <- event
+	}); err != nil {
+		return nil, err
+	}
+
+	events := make(chan TxStatusEvent)
+	go s.streamTxEvents(ctx, chainData.ID, input.TxHash, results, events)
+	return events, nil
+}
+
+func (s *Service) streamTxEvents(ctx context.Context, chainID string, txHash string, results <-chan connection.TxEvent, events chan<- TxStatusEvent) {
+	defer close(events)
+
+	send := func(event TxStatusEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	if !send(TxStatusEvent{Stage: TxStageBroadcast, TxHash: txHash}) {
+		return
+	}
+
+	ticker := time.NewTicker(mempoolPollInterval)
+	defer ticker.Stop()
+	checkMempool := func() bool {
+		inMempool, err := s.cosmosClient.IsTxInMempool(ctx, chainID, txHash)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Error(err)
+		}
+		return inMempool
+	}
+
+	inMempool := checkMempool()
+	if inMempool && !send(TxStatusEvent{Stage: TxStageMempool, TxHash: txHash}) {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if inMempool {
+				continue
+			}
+			if inMempool = checkMempool(); inMempool && !send(TxStatusEvent{Stage: TxStageMempool, TxHash: txHash}) {
+				return
+			}
+		case result := <-results:
+			if result.Status == connection.TxStatusTimeout {
+				send(TxStatusEvent{Stage: TxStageTimeout, TxHash: txHash})
+				return
+			}
+
+			if !send(TxStatusEvent{Stage: TxStageIncluded, TxHash: txHash, Height: result.Height}) {
+				return
+			}
+
+			event := TxStatusEvent{
+				Stage:     TxStageSuccess,
+				TxHash:    txHash,
+				Height:    result.Height,
+				Code:      result.Code,
+				Log:       result.Log,
+				GasUsed:   result.GasUsed,
+				GasWanted: result.GasWanted,
+			}
+			if result.Status == connection.TxStatusFailure {
+				event.Stage = TxStageFailure
+				event.Reason = string(cosmos.ParseFailureReason(result.Log))
+			}
+			send(event)
+			return
+		}
+	}
+}
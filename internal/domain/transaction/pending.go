@@ -0,0 +1,102 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Mobile-Web3/backend/internal/notify"
+	"github.com/Mobile-Web3/backend/pkg/cosmos"
+	"github.com/Mobile-Web3/backend/pkg/cosmos/connection"
+)
+
+// PendingTx is a broadcast tx, which result has not been delivered yet.
+type PendingTx struct {
+	ChainID  string        `json:"chainId"`
+	TxHash   string        `json:"txHash"`
+	Target   notify.Target `json:"target"`
+	Deadline time.Time     `json:"deadline"`
+	// Amount, Recipient and ChainName are shown in notification texts.
+	Amount    string `json:"amount,omitempty"`
+	Symbol    string `json:"symbol,omitempty"`
+	Recipient string `json:"recipient,omitempty"`
+	ChainName string `json:"chainName,omitempty"`
+}
+
+type PendingRepository interface {
+	GetAll(ctx context.Context) ([]PendingTx, error)
+	Add(ctx context.Context, tx PendingTx) error
+	Remove(ctx context.Context, chainID string, txHash string) error
+}
+
+// trackTx awaits result of the pending tx and removes it from the repository, when its notification is queued.
+// Queued notifications are persisted by notifier and delivered after restart, so the result is not lost.
+// Tx, which notification is not queued, is kept in the repository and its result is sent again on resume.
+func (s *Service) trackTx(pending PendingTx) {
+	err := s.cosmosClient.TrackTx(context.Background(), pending.ChainID, pending.TxHash, pending.Deadline, func(event connection.TxEvent) {
+		ctx := context.Background()
+		if err := s.notifier.Notify(ctx, pending.Target, txResultMessage(pending, event)); err != nil {
+			s.logger.Error(fmt.Errorf("notifying result of tx %s; %w", pending.TxHash, err))
+			return
+		}
+
+		if err := s.pendingRepository.Remove(ctx, pending.ChainID, pending.TxHash); err != nil {
+			s.logger.Error(err)
+		}
+	})
+	if err == nil {
+		return
+	}
+
+	// tx with invalid hash can't be tracked, so it is not kept
+	s.logger.Error(fmt.Errorf("tracking tx %s; %w", pending.TxHash, err))
+	if err = s.pendingRepository.Remove(context.Background(), pending.ChainID, pending.TxHash); err != nil {
+		s.logger.Error(err)
+	}
+}
+
+func txResultMessage(pending PendingTx, event connection.TxEvent) notify.Message {
+	chainName := pending.ChainName
+	if chainName == "" {
+		chainName = pending.ChainID
+	}
+
+	message := notify.Message{
+		Type: "tx_result",
+		Data: map[string]string{
+			"txHash":    event.TxHash,
+			"chainId":   pending.ChainID,
+			"chainName": chainName,
+			"amount":    pending.Amount,
+			"symbol":    pending.Symbol,
+			"recipient": pending.Recipient,
+			"status":    string(event.Status),
+			"isSuccess": fmt.Sprintf("%t", event.Status == connection.TxStatusSuccess),
+			"info":      event.Info,
+			"gasUsed":   fmt.Sprintf("%d", event.GasUsed),
+			"gasWanted": fmt.Sprintf("%d", event.GasWanted),
+			"log":       event.Log,
+		},
+	}
+
+	if event.Status == connection.TxStatusFailure {
+		message.Data["reason"] = string(cosmos.ParseFailureReason(event.Log))
+	}
+
+	return message
+}
+
+// ResumePendingTransactions continues tracking of txs left after restart,
+// results of txs with expired deadline are checked once and delivered.
+func (s *Service) ResumePendingTransactions(ctx context.Context) error {
+	pendingTxs, err := s.pendingRepository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, pending := range pendingTxs {
+		s.trackTx(pending)
+	}
+
+	return nil
+}
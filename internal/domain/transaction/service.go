@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/internal/domain/price"
+	"github.com/Mobile-Web3/backend/internal/notify"
 	"github.com/Mobile-Web3/backend/pkg/cosmos"
 	"github.com/Mobile-Web3/backend/pkg/log"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -18,18 +21,34 @@ import (
 )
 
 type Service struct {
-	gasAdjustment   float64
-	logger          log.Logger
-	chainRepository chain.Repository
-	cosmosClient    *cosmos.Client
+	gasAdjustment     float64
+	txTrackingTimeout time.Duration
+	logger            log.Logger
+	chainRepository   chain.Repository
+	cosmosClient      *cosmos.Client
+	prices            *price.Service
+	pendingRepository PendingRepository
+	notifier          notify.Notifier
 }
 
-func NewService(gasAdjustment float64, logger log.Logger, chainRepository chain.Repository, cosmosClient *cosmos.Client) *Service {
+func NewService(
+	gasAdjustment float64,
+	txTrackingTimeout time.Duration,
+	logger log.Logger,
+	chainRepository chain.Repository,
+	cosmosClient *cosmos.Client,
+	prices *price.Service,
+	pendingRepository PendingRepository,
+	notifier notify.Notifier) *Service {
 	return &Service{
-		gasAdjustment:   gasAdjustment,
-		logger:          logger,
-		chainRepository: chainRepository,
-		cosmosClient:    cosmosClient,
+		gasAdjustment:     gasAdjustment,
+		txTrackingTimeout: txTrackingTimeout,
+		logger:            logger,
+		chainRepository:   chainRepository,
+		cosmosClient:      cosmosClient,
+		prices:            prices,
+		pendingRepository: pendingRepository,
+		notifier:          notifier,
 	}
 }
 
@@ -139,7 +158,7 @@ func (s *Service) SendTransaction(ctx context.Context, input SendInput) (SendRes
 		GasPrice:    gasPrice,
 		ChainPrefix: fromChain.Prefix,
 		Key:         input.Key,
-		Message:     msgSend,
+		Messages:    []sdk.Msg{msgSend},
 	})
 	if err != nil {
 		return SendResponse{}, err
@@ -173,6 +192,22 @@ type SendInputFirebase struct {
 	GasAdjusted   string `json:"gasAdjusted"`
 	GasPrice      string `json:"gasPrice"`
 	FirebaseToken string `json:"firebaseToken"`
+	// Locale of notification texts for firebase token, e.g. "en" or "ru".
+	Locale string `json:"locale"`
+	// Notification is a channel for tx result, firebase token is used when it is not set.
+	Notification *notify.Target `json:"notification"`
+}
+
+func (input SendInputFirebase) target() notify.Target {
+	if input.Notification != nil {
+		return *input.Notification
+	}
+
+	return notify.Target{
+		Channel: notify.ChannelFCM,
+		Token:   input.FirebaseToken,
+		Locale:  input.Locale,
+	}
 }
 
 func (input SendInputFirebase) Validate() error {
@@ -205,10 +240,16 @@ func (input SendInputFirebase) Validate() error {
 		errs = append(errs, "invalid gasPrice")
 	}
 
-	if input.FirebaseToken == "" {
+	if input.Notification == nil && input.FirebaseToken == "" {
 		errs = append(errs, "invalid firebaseToken")
 	}
 
+	if input.Notification != nil {
+		if err := input.Notification.Validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
 	if len(errs) > 0 {
 		return formatErrors(errs)
 	}
@@ -217,8 +258,11 @@ func (input SendInputFirebase) Validate() error {
 }
 
 type SendResponseFirebase struct {
-	TxHash     string `json:"txHash"`
-	WithEvents bool   `json:"withEvents"`
+	TxHash string `json:"txHash"`
+	// WithEvents is false, when tx is broadcast, but its tracking is not saved, so result could be lost on restart.
+	WithEvents bool `json:"withEvents"`
+	// WebhookSecret is the secret of webhook notification signatures, it is generated for every transaction.
+	WebhookSecret string `json:"webhookSecret,omitempty"`
 }
 
 func (s *Service) SendTransactionWithEvents(ctx context.Context, input SendInputFirebase) (SendResponseFirebase, error) {
@@ -254,6 +298,12 @@ func (s *Service) SendTransactionWithEvents(ctx context.Context, input SendInput
 		return SendResponseFirebase{}, err
 	}
 
+	target, err := input.target().WithSecret()
+	if err != nil {
+		s.logger.Error(err)
+		return SendResponseFirebase{}, err
+	}
+
 	msgSend := &bank.MsgSend{
 		FromAddress: input.From,
 		ToAddress:   input.To,
@@ -267,49 +317,58 @@ func (s *Service) SendTransactionWithEvents(ctx context.Context, input SendInput
 		GasPrice:    gasPrice,
 		ChainPrefix: fromChain.Prefix,
 		Key:         input.Key,
-		Message:     msgSend,
+		Messages:    []sdk.Msg{msgSend},
 	})
 	if err != nil {
 		return SendResponseFirebase{}, err
 	}
 
-	withEvents := true
-	websocketClient := s.cosmosClient.GetChainWebsocketClient(fromChain.ID)
-	subscriber, err := websocketClient.SubscribeToTx(ctx, input.From, map[string]interface{}{
-		"token": input.FirebaseToken,
-	})
-	if err != nil {
-		withEvents = false
-	}
-
 	rpcClient := s.cosmosClient.GetChainHttpClient(fromChain.ID)
 	response, err := rpcClient.BroadcastTxSync(ctx, txBytes)
 	if err != nil {
-		websocketClient.UnsubscribeFromTx(subscriber)
 		s.logger.Error(err)
 		return SendResponseFirebase{}, err
 	}
 
 	if response.Code != 0 {
-		websocketClient.UnsubscribeFromTx(subscriber)
 		err = fmt.Errorf("transaction failed with code: %d; TxHash: %s; log: %s", response.Code, response.Hash.String(), response.Log)
 		s.logger.Error(err)
 		return SendResponseFirebase{}, err
 	}
 
+	pending := PendingTx{
+		ChainID:   fromChain.ID,
+		TxHash:    response.Hash.String(),
+		Target:    target,
+		Deadline:  time.Now().Add(s.txTrackingTimeout),
+		Amount:    fmt.Sprintf("%s %s", input.Amount, fromChain.Asset.Symbol),
+		Symbol:    fromChain.Asset.Symbol,
+		Recipient: input.To,
+		ChainName: fromChain.PrettyName,
+	}
+	// tx is already broadcast, so error is not returned to prevent sending it again
+	withEvents := true
+	if err = s.pendingRepository.Add(ctx, pending); err != nil {
+		s.logger.Error(fmt.Errorf("saving pending tx %s; %w", pending.TxHash, err))
+		withEvents = false
+	}
+	s.trackTx(pending)
+
 	return SendResponseFirebase{
-		TxHash:     response.Hash.String(),
-		WithEvents: withEvents,
+		TxHash:        response.Hash.String(),
+		WithEvents:    withEvents,
+		WebhookSecret: target.Secret,
 	}, nil
 }
 
 type SimulateInput struct {
-	ChainID string `json:"chainId"`
-	From    string `json:"from"`
-	To      string `json:"to"`
-	Amount  string `json:"amount"`
-	Key     string `json:"key"`
-	Memo    string `json:"memo"`
+	ChainID  string `json:"chainId"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Amount   string `json:"amount"`
+	Key      string `json:"key"`
+	Memo     string `json:"memo"`
+	Currency string `json:"currency"`
 }
 
 func (input SimulateInput) Validate() error {
@@ -342,10 +401,14 @@ func (input SimulateInput) Validate() error {
 }
 
 type SimulateResponse struct {
-	GasAdjusted     string `json:"gasAdjusted"`
-	LowGasPrice     string `json:"lowGasPrice"`
-	AverageGasPrice string `json:"averageGasPrice"`
-	HighGasPrice    string `json:"highGasPrice"`
+	GasAdjusted         string `json:"gasAdjusted"`
+	LowGasPrice         string `json:"lowGasPrice"`
+	AverageGasPrice     string `json:"averageGasPrice"`
+	HighGasPrice        string `json:"highGasPrice"`
+	Currency            string `json:"currency"`
+	LowGasPriceFiat     string `json:"lowGasPriceFiat"`
+	AverageGasPriceFiat string `json:"averageGasPriceFiat"`
+	HighGasPriceFiat    string `json:"highGasPriceFiat"`
 }
 
 func (s *Service) SimulateTransaction(ctx context.Context, input SimulateInput) (SimulateResponse, error) {
@@ -385,7 +448,7 @@ func (s *Service) SimulateTransaction(ctx context.Context, input SimulateInput)
 		Memo:        input.Memo,
 		ChainPrefix: fromChain.Prefix,
 		Key:         input.Key,
-		Message:     msgSend,
+		Messages:    []sdk.Msg{msgSend},
 	})
 	if err != nil {
 		return SimulateResponse{}, err
@@ -428,10 +491,22 @@ func (s *Service) SimulateTransaction(ctx context.Context, input SimulateInput)
 	averageGasPrice := math.Round(gasAdjusted*fromChain.AverageGasPrice) / divider
 	highGasPrice := math.Round(gasAdjusted*fromChain.HighGasPrice) / divider
 
-	return SimulateResponse{
+	simulateResponse := SimulateResponse{
 		GasAdjusted:     fmt.Sprintf("%.0f", gasAdjusted),
 		LowGasPrice:     fmt.Sprintf("%f", lowGasPrice),
 		AverageGasPrice: fmt.Sprintf("%f", averageGasPrice),
 		HighGasPrice:    fmt.Sprintf("%f", highGasPrice),
-	}, nil
+		Currency:        s.prices.Currency(input.Currency),
+	}
+
+	assetPrice, err := s.prices.GetPrice(ctx, fromChain.Asset, simulateResponse.Currency)
+	if err != nil {
+		s.logger.Error(err)
+		return simulateResponse, nil
+	}
+
+	simulateResponse.LowGasPriceFiat = price.FiatValue(simulateResponse.LowGasPrice, assetPrice)
+	simulateResponse.AverageGasPriceFiat = price.FiatValue(simulateResponse.AverageGasPrice, assetPrice)
+	simulateResponse.HighGasPriceFiat = price.FiatValue(simulateResponse.HighGasPrice, assetPrice)
+	return simulateResponse, nil
 }
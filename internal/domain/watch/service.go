@@ -0,0 +1,450 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/internal/notify"
+	"github.com/Mobile-Web3/backend/pkg/cosmos"
+	"github.com/Mobile-Web3/backend/pkg/cosmos/connection"
+	"github.com/Mobile-Web3/backend/pkg/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/google/uuid"
+)
+
+// maxWatchesPerTarget is a count of addresses watched by the same target.
+const maxWatchesPerTarget = 100
+
+var (
+	ErrWatchNotFound  = errors.New("watch not found")
+	ErrTooManyWatches = errors.New("too many watches of the notification target")
+)
+
+// Alert is a kind of notifications about the watched address.
+type Alert string
+
+const (
+	// AlertTransfers notifies about incoming transfers.
+	AlertTransfers Alert = "transfers"
+	// AlertValidators notifies delegators about jailing, leaving active set and commission increases of their validators.
+	AlertValidators Alert = "validators"
+	// AlertGovernance notifies about proposals in voting period.
+	AlertGovernance Alert = "governance"
+)
+
+// Watch is a request to notify the target about events of the address.
+type Watch struct {
+	ID      string        `json:"id"`
+	ChainID string        `json:"chainId"`
+	Address string        `json:"address"`
+	Target  notify.Target `json:"target"`
+	// Alerts are kinds of notifications, all of them are sent when it is empty.
+	Alerts    []Alert   `json:"alerts,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (w Watch) Has(alert Alert) bool {
+	if len(w.Alerts) == 0 {
+		return true
+	}
+
+	for _, item := range w.Alerts {
+		if item == alert {
+			return true
+		}
+	}
+	return false
+}
+
+func sameAlerts(first []Alert, second []Alert) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	for index := range first {
+		if first[index] != second[index] {
+			return false
+		}
+	}
+	return true
+}
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]Watch, error)
+	Add(ctx context.Context, watch Watch) error
+	Remove(ctx context.Context, id string) error
+}
+
+type Service struct {
+	logger          log.Logger
+	chainRepository chain.Repository
+	repository      Repository
+	cosmosClient    *cosmos.Client
+	notifier        notify.Notifier
+
+	mutex   sync.RWMutex
+	watches map[string]Watch
+	// recipients are chain addresses, which transfers are watched.
+	recipients map[string]struct{}
+	// transfers are stop functions of transfer subscriptions by chain, one subscription is shared by all addresses of the chain.
+	transfers map[string]func()
+}
+
+func NewService(
+	logger log.Logger,
+	chainRepository chain.Repository,
+	repository Repository,
+	cosmosClient *cosmos.Client,
+	notifier notify.Notifier) *Service {
+	return &Service{
+		logger:          logger,
+		chainRepository: chainRepository,
+		repository:      repository,
+		cosmosClient:    cosmosClient,
+		notifier:        notifier,
+		watches:         make(map[string]Watch),
+		recipients:      make(map[string]struct{}),
+		transfers:       make(map[string]func()),
+	}
+}
+
+func formatErrors(errs []string) error {
+	result := errs[0]
+	for i := 1; i < len(errs); i++ {
+		result = fmt.Sprintf("%s; %s", result, errs[i])
+	}
+	return errors.New(result)
+}
+
+func addressKey(chainID string, address string) string {
+	return fmt.Sprintf("%s/%s", chainID, address)
+}
+
+// syncTransfers watches transfers of the address when any its watch needs them, subscription of the chain
+// is started for the first watched address and stopped when the chain has no watched addresses left.
+// Must be called with locked mutex.
+func (s *Service) syncTransfers(chainID string, address string) {
+	key := addressKey(chainID, address)
+	needed := false
+	for _, watch := range s.watches {
+		if watch.ChainID == chainID && watch.Address == address && watch.Has(AlertTransfers) {
+			needed = true
+			break
+		}
+	}
+
+	if needed {
+		s.recipients[key] = struct{}{}
+	} else {
+		delete(s.recipients, key)
+	}
+
+	chainNeeded := false
+	for recipient := range s.recipients {
+		if strings.HasPrefix(recipient, addressKey(chainID, "")) {
+			chainNeeded = true
+			break
+		}
+	}
+
+	stop, running := s.transfers[chainID]
+	switch {
+	case chainNeeded && !running:
+		s.transfers[chainID] = s.cosmosClient.WatchTransfers(chainID, func(recipient string) bool {
+			return s.isRecipient(chainID, recipient)
+		}, func(event connection.TransferEvent) {
+			s.notifyTransfer(chainID, event.Recipient, event)
+		})
+	case !chainNeeded && running:
+		stop()
+		delete(s.transfers, chainID)
+	}
+}
+
+func (s *Service) isRecipient(chainID string, address string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	_, ok := s.recipients[addressKey(chainID, address)]
+	return ok
+}
+
+// GetWatches returns watches, which need notifications of the alert kind.
+func (s *Service) GetWatches(alert Alert) []Watch {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var result []Watch
+	for _, watch := range s.watches {
+		if watch.Has(alert) {
+			result = append(result, watch)
+		}
+	}
+	return result
+}
+
+func (s *Service) notifyTransfer(chainID string, address string, event connection.TransferEvent) {
+	var targets []notify.Target
+	for _, watch := range s.GetWatches(AlertTransfers) {
+		if watch.ChainID == chainID && watch.Address == address {
+			targets = append(targets, watch.Target)
+		}
+	}
+
+	chainName, symbol, amount := chainID, "", event.Amount
+	if chainData, err := s.chainRepository.GetByID(context.Background(), chainID); err == nil {
+		if chainData.PrettyName != "" {
+			chainName = chainData.PrettyName
+		}
+		symbol = chainData.Asset.Symbol
+		amount = formatAmount(event.Amount, chainData.Asset)
+	}
+
+	message := notify.Message{
+		Type: "transfer_received",
+		Data: map[string]string{
+			"chainId":   chainID,
+			"chainName": chainName,
+			"txHash":    event.TxHash,
+			"height":    fmt.Sprintf("%d", event.Height),
+			"recipient": event.Recipient,
+			"senders":   strings.Join(event.Senders, ","),
+			"amount":    amount,
+			"symbol":    symbol,
+			"coins":     event.Amount,
+			"channel":   event.Channel,
+		},
+	}
+
+	for _, target := range targets {
+		if err := s.notifier.Notify(context.Background(), target, message); err != nil {
+			s.logger.Error(err)
+		}
+	}
+}
+
+// formatAmount converts native coins to display amount with symbol, other denoms are left as is.
+func formatAmount(amount string, asset chain.Asset) string {
+	coins, err := sdk.ParseCoinsNormalized(amount)
+	if err != nil {
+		return amount
+	}
+
+	result := make([]string, 0, len(coins))
+	for _, coin := range coins {
+		if coin.Denom == asset.Base {
+			if display, displayErr := chain.FormatDisplayAmount(coin.Amount.String(), asset); displayErr == nil {
+				result = append(result, display)
+				continue
+			}
+		}
+		result = append(result, coin.String())
+	}
+
+	return strings.Join(result, ", ")
+}
+
+// ResumeWatches subscribes to transfers of persisted watches.
+func (s *Service) ResumeWatches(ctx context.Context) error {
+	watches, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, watch := range watches {
+		s.watches[watch.ID] = watch
+		s.syncTransfers(watch.ChainID, watch.Address)
+	}
+
+	return nil
+}
+
+type CreateInput struct {
+	ChainID      string        `json:"chainId"`
+	Address      string        `json:"address"`
+	Notification notify.Target `json:"notification"`
+	// Alerts are kinds of notifications: transfers, validators, governance; all of them when it is empty.
+	Alerts []Alert `json:"alerts"`
+}
+
+func (input CreateInput) Validate() error {
+	var errs []string
+	if input.ChainID == "" {
+		errs = append(errs, "invalid chainId")
+	}
+
+	if input.Address == "" {
+		errs = append(errs, "invalid address")
+	}
+
+	if err := input.Notification.Validate(); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	for _, alert := range input.Alerts {
+		if alert != AlertTransfers && alert != AlertValidators && alert != AlertGovernance {
+			errs = append(errs, fmt.Sprintf("invalid alert %s", alert))
+		}
+	}
+
+	if len(errs) > 0 {
+		return formatErrors(errs)
+	}
+
+	return nil
+}
+
+// Create registers the watch, the same address watched by the same target is registered once,
+// and its locale and alerts are updated by repeated registration.
+// Target can watch up to maxWatchesPerTarget addresses.
+// Webhook target gets the new secret on every registration, it is returned in the created watch only.
+func (s *Service) Create(ctx context.Context, input CreateInput) (Watch, error) {
+	chainData, err := s.chainRepository.GetByID(ctx, input.ChainID)
+	if err != nil {
+		return Watch{}, err
+	}
+
+	prefix, addressBytes, err := s.cosmosClient.ParseAddress(input.Address)
+	if err != nil {
+		return Watch{}, err
+	}
+
+	if prefix != "" && prefix != chainData.Prefix {
+		err = fmt.Errorf("address prefix %s does not belong to chain %s", prefix, chainData.ID)
+		return Watch{}, err
+	}
+
+	address, err := s.cosmosClient.ConvertAddressPrefix(chainData.Prefix, addressBytes)
+	if err != nil {
+		return Watch{}, err
+	}
+
+	target, err := input.Notification.WithSecret()
+	if err != nil {
+		return Watch{}, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	watch := Watch{
+		ID:        uuid.New().String(),
+		ChainID:   chainData.ID,
+		Address:   address,
+		Target:    target,
+		Alerts:    input.Alerts,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	targetWatches := 0
+	for _, existing := range s.watches {
+		if existing.Target.Same(watch.Target) {
+			targetWatches++
+		}
+	}
+
+	replaced := false
+	for _, existing := range s.watches {
+		if existing.ChainID != watch.ChainID || existing.Address != watch.Address || !existing.Target.Same(watch.Target) {
+			continue
+		}
+
+		// webhook secrets differ, so webhook registered again gets its watch with the new secret
+		if existing.Target == watch.Target && sameAlerts(existing.Alerts, watch.Alerts) {
+			return existing, nil
+		}
+
+		// device registers again with another locale or alerts
+		if err = s.repository.Remove(ctx, existing.ID); err != nil {
+			return Watch{}, err
+		}
+		delete(s.watches, existing.ID)
+		watch.ID = existing.ID
+		watch.CreatedAt = existing.CreatedAt
+		replaced = true
+		break
+	}
+
+	if !replaced && targetWatches >= maxWatchesPerTarget {
+		return Watch{}, ErrTooManyWatches
+	}
+
+	if err = s.repository.Add(ctx, watch); err != nil {
+		return Watch{}, err
+	}
+
+	s.watches[watch.ID] = watch
+	s.syncTransfers(watch.ChainID, watch.Address)
+	return watch, nil
+}
+
+type RemoveInput struct {
+	ID string `json:"-"`
+	// Notification is the target, which has created the watch, only it can remove the watch.
+	Notification notify.Target `json:"notification"`
+}
+
+func (input RemoveInput) Validate() error {
+	var errs []string
+	if input.ID == "" {
+		errs = append(errs, "invalid id")
+	}
+
+	if input.Notification.Token == "" && input.Notification.URL == "" {
+		errs = append(errs, "invalid notification")
+	}
+
+	if len(errs) > 0 {
+		return formatErrors(errs)
+	}
+
+	return nil
+}
+
+// Remove deletes the watch of the target, subscription is stopped when the address has no watches left.
+// Watch of another target is reported as not found, so its existence is not disclosed.
+func (s *Service) Remove(ctx context.Context, input RemoveInput) (Watch, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	watch, ok := s.watches[input.ID]
+	if !ok || !watch.Target.Same(input.Notification) {
+		return Watch{}, ErrWatchNotFound
+	}
+
+	if err := s.repository.Remove(ctx, input.ID); err != nil {
+		return Watch{}, err
+	}
+
+	delete(s.watches, input.ID)
+	s.syncTransfers(watch.ChainID, watch.Address)
+	watch.Target = watch.Target.Public()
+	return watch, nil
+}
+
+// RemoveByTarget deletes all watches of the target, it is used to prune targets, which no longer accept notifications.
+func (s *Service) RemoveByTarget(ctx context.Context, target notify.Target) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for id, watch := range s.watches {
+		if !watch.Target.Same(target) {
+			continue
+		}
+
+		if err := s.repository.Remove(ctx, id); err != nil {
+			return err
+		}
+		delete(s.watches, id)
+		s.syncTransfers(watch.ChainID, watch.Address)
+	}
+
+	return nil
+}
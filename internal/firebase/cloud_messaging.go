@@ -6,7 +6,7 @@ import (
 
 	fcm "firebase.google.com/go"
 	"firebase.google.com/go/messaging"
-	"github.com/Mobile-Web3/backend/pkg/cosmos/connection"
+	"github.com/Mobile-Web3/backend/internal/notify"
 	"github.com/Mobile-Web3/backend/pkg/log"
 	"google.golang.org/api/option"
 )
@@ -35,34 +35,39 @@ func NewCloudMessagingClient(keyPath string, logger log.Logger) (*CloudMessaging
 	}, nil
 }
 
-func (c *CloudMessagingClient) SendTxResult(ctx context.Context, event connection.TxEvent, params map[string]interface{}) error {
-	tokenParam, ok := params["token"]
-	if !ok {
-		err := fmt.Errorf("not found firebase token in params")
-		c.logger.Error(err)
-		return err
+func (c *CloudMessagingClient) Notify(ctx context.Context, target notify.Target, message notify.Message) error {
+	data := make(map[string]string, len(message.Data)+1)
+	for key, value := range message.Data {
+		data[key] = value
 	}
+	data["type"] = message.Type
+	// tx log is too long for data payload limited to 4KB, it is available in tx details
+	delete(data, "log")
 
-	token, ok := tokenParam.(string)
-	if !ok {
-		err := fmt.Errorf("invalid firebase token: %v", tokenParam)
-		c.logger.Error(err)
-		return err
+	fcmMessage := &messaging.Message{
+		Token: target.Token,
+		Data:  data,
 	}
 
-	message := &messaging.Message{
-		Token: token,
-		Data: map[string]string{
-			"txHash":    event.TxHash,
-			"isSuccess": fmt.Sprintf("%t", event.Code == 0),
-			"info":      event.Info,
-			"gasUsed":   fmt.Sprintf("%d", event.GasUsed),
-			"gasWanted": fmt.Sprintf("%d", event.GasWanted),
-			"log":       event.Log,
-		},
+	if message.Title != "" || message.Body != "" {
+		fcmMessage.Notification = &messaging.Notification{
+			Title: message.Title,
+			Body:  message.Body,
+		}
 	}
 
-	_, err := c.client.Send(ctx, message)
+	_, err := c.client.Send(ctx, fcmMessage)
+	// only unregistered token is pruned, invalid argument is returned for malformed message as well
+	if messaging.IsRegistrationTokenNotRegistered(err) {
+		err = fmt.Errorf("firebase cloud messaging; %w: %s", notify.ErrInvalidTarget, err.Error())
+		c.logger.Error(err)
+		return err
+	}
+	if messaging.IsInvalidArgument(err) || messaging.IsMismatchedCredential(err) {
+		err = fmt.Errorf("firebase cloud messaging; %w: %s", notify.ErrRejected, err.Error())
+		c.logger.Error(err)
+		return err
+	}
 	if err != nil {
 		err = fmt.Errorf("firebase cloud messaging; %s", err.Error())
 		c.logger.Error(err)
@@ -159,7 +159,16 @@ func (c *ChainRegistryClient) uploadChain(chainURL string, assetURL string, stor
 			return
 		}
 
+		rest, err := chain.ValidateRPCUrls(chainData.Api.Rest)
+		if err != nil {
+			c.logger.Error(err)
+			storage.addChain(chain.Chain{}, err)
+			return
+		}
+
 		chainData.Api.Rpc = rpc
+		chainData.Api.Rest = rest
+		chainData.Api.Grpc = chain.ValidateGRPCUrls(chainData.Api.Grpc)
 		storage.addChain(chainData, nil)
 	}
 }
@@ -6,7 +6,11 @@ import (
 	_ "github.com/Mobile-Web3/backend/docs/api"
 	"github.com/Mobile-Web3/backend/internal/domain/account"
 	"github.com/Mobile-Web3/backend/internal/domain/chain"
+	"github.com/Mobile-Web3/backend/internal/domain/delivery"
+	"github.com/Mobile-Web3/backend/internal/domain/multisig"
+	"github.com/Mobile-Web3/backend/internal/domain/restake"
 	"github.com/Mobile-Web3/backend/internal/domain/transaction"
+	"github.com/Mobile-Web3/backend/internal/domain/watch"
 	v1 "github.com/Mobile-Web3/backend/internal/handler/http/v1"
 	"github.com/Mobile-Web3/backend/pkg/log"
 	"github.com/gin-gonic/gin"
@@ -19,12 +23,23 @@ type Dependencies struct {
 	ChainService       *chain.Service
 	AccountService     *account.Service
 	TransactionService *transaction.Service
+	MultisigService    *multisig.Service
+	WatchService       *watch.Service
+	DeliveryService    *delivery.Service
+	// RestakeService enables restake endpoints, they are not registered when it is nil.
+	RestakeService *restake.Service
+	// AdminToken enables admin endpoints, they are not registered when it is empty.
+	AdminToken string
 }
 
 func NewHandler(dependencies *Dependencies) http.Handler {
 	accountsController := v1.NewAccountsController(dependencies.Logger, dependencies.AccountService)
 	chainsController := v1.NewChainsController(dependencies.Logger, dependencies.Repository, dependencies.ChainService)
 	transactionsController := v1.NewTransactionsController(dependencies.Logger, dependencies.TransactionService)
+	multisigController := v1.NewMultisigController(dependencies.Logger, dependencies.MultisigService)
+	watchesController := v1.NewWatchesController(dependencies.Logger, dependencies.WatchService)
+	adminController := v1.NewAdminController(dependencies.Logger, dependencies.DeliveryService)
+	restakeController := v1.NewRestakeController(dependencies.Logger, dependencies.RestakeService)
 
 	gin.SetMode("release")
 	router := gin.New()
@@ -41,13 +56,20 @@ func NewHandler(dependencies *Dependencies) http.Handler {
 			accounts.POST("mnemonic", accountsController.CreateMnemonic())
 			accounts.POST("create", accountsController.CreateAccount())
 			accounts.POST("restore", accountsController.RestoreAccount())
+			accounts.POST("sign-arbitrary", accountsController.SignArbitrary())
+			accounts.POST("verify-arbitrary", accountsController.VerifyArbitrary())
 			accounts.GET("balance", accountsController.GetBalance)
+			accounts.POST("portfolio", accountsController.GetPortfolio())
+			accounts.GET("address/convert", accountsController.ConvertAddress)
 		}
 
 		chains := api.Group("chains")
 		{
 			chains.GET("", chainsController.GetChains())
 			chains.GET(":id/validators", chainsController.GetPagedValidators)
+			chains.GET(":id/validators/:address", chainsController.GetValidator)
+			chains.GET(":id/staking", chainsController.GetStaking)
+			chains.GET(":id/endpoints", chainsController.GetEndpoints)
 		}
 
 		transactions := api.Group("transactions")
@@ -55,6 +77,38 @@ func NewHandler(dependencies *Dependencies) http.Handler {
 			transactions.POST("send", transactionsController.SendTransaction())
 			transactions.POST("send/firebase", transactionsController.SendTransactionFirebase())
 			transactions.POST("simulate", transactionsController.SimulateTransaction())
+			transactions.GET(":chainId/:hash/events", transactionsController.StreamTransactionEvents)
+		}
+
+		multisigs := api.Group("multisig")
+		{
+			multisigs.POST("create", multisigController.CreateAccount())
+			multisigs.POST("tx/create", multisigController.CreateTransaction())
+			multisigs.POST("tx/sign", multisigController.SignTransaction())
+			multisigs.POST("tx/combine", multisigController.CombineTransaction())
+		}
+
+		watches := api.Group("watches")
+		{
+			watches.POST("", watchesController.CreateWatch())
+			watches.DELETE(":id", watchesController.RemoveWatch)
+		}
+
+		if dependencies.RestakeService != nil {
+			restakes := api.Group("restake")
+			{
+				restakes.POST("grant", restakeController.Grant())
+				restakes.POST("revoke", restakeController.Revoke())
+				restakes.GET(":chainId/:address/runs", restakeController.GetRuns)
+			}
+		}
+
+		if dependencies.AdminToken != "" {
+			admin := api.Group("admin", adminMiddleware(dependencies.AdminToken))
+			{
+				admin.GET("deliveries/:txHash", adminController.GetDeliveries)
+				admin.GET("dead-letters", adminController.GetDeadLetters())
+			}
 		}
 	}
 
@@ -1,8 +1,10 @@
 package http
 
 import (
+	"crypto/subtle"
 	"errors"
 	"net"
+	"net/http"
 	"os"
 	"strings"
 
@@ -51,3 +53,17 @@ func recoverMiddleware(logger log.Logger) gin.HandlerFunc {
 func metricsMiddleware(_ *gin.Context) {
 	metrics.RpsCounter.Incr(1)
 }
+
+// adminMiddleware allows requests with "Authorization: Bearer <token>" header only.
+func adminMiddleware(token string) gin.HandlerFunc {
+	expected := []byte("Bearer " + token)
+	return func(context *gin.Context) {
+		actual := []byte(context.GetHeader("Authorization"))
+		if subtle.ConstantTimeCompare(actual, expected) != 1 {
+			context.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		context.Next()
+	}
+}
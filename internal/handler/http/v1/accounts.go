@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"strings"
+
 	"github.com/Mobile-Web3/backend/internal/domain/account"
 	"github.com/Mobile-Web3/backend/pkg/log"
 	"github.com/gin-gonic/gin"
@@ -57,6 +59,32 @@ func (c *AccountsController) RestoreAccount() gin.HandlerFunc {
 	return newRequestHandler(c.service.RestoreAccount, c.logger)
 }
 
+// SignArbitrary godoc
+// @Summary      Подпись произвольных данных (ADR-036)
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @param        request body account.SignArbitraryInput true "body"
+// @Success      200 {object} apiResponse{result=account.SignArbitraryResponse}
+// @Router       /v1/accounts/sign-arbitrary [post]
+func (c *AccountsController) SignArbitrary() gin.HandlerFunc {
+	return newRequestHandler(c.service.SignArbitrary, c.logger)
+}
+
+// VerifyArbitrary godoc
+// @Summary      Проверка подписи произвольных данных (ADR-036)
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @param        request body account.VerifyArbitraryInput true "body"
+// @Success      200 {object} apiResponse{result=account.VerifyArbitraryResponse}
+// @Router       /v1/accounts/verify-arbitrary [post]
+func (c *AccountsController) VerifyArbitrary() gin.HandlerFunc {
+	return newRequestHandler(c.service.VerifyArbitrary, c.logger)
+}
+
 // GetBalance godoc
 // @Summary      Получить инфу о балансе
 // @Tags         accounts
@@ -65,13 +93,52 @@ func (c *AccountsController) RestoreAccount() gin.HandlerFunc {
 // @Content-Type application/json
 // @Param        chainId query string  true "id сети"
 // @Param        address query string  true "адрес кошелька"
+// @Param        currency query string false "фиатная валюта, например usd"
 // @Success      200 {object} apiResponse{result=account.BalanceResponse}
 // @Router       /v1/accounts/balance [get]
 func (c *AccountsController) GetBalance(context *gin.Context) {
 	request := account.BalanceInput{
-		ChainID: context.Query("chainId"),
-		Address: context.Query("address"),
+		ChainID:  context.Query("chainId"),
+		Address:  context.Query("address"),
+		Currency: context.Query("currency"),
 	}
 
 	handleRequest(request, context, c.service.CheckBalance)
 }
+
+// GetPortfolio godoc
+// @Summary      Получить портфель по нескольким сетям
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @param        request body account.PortfolioInput true "body"
+// @Success      200 {object} apiResponse{result=account.PortfolioResponse}
+// @Router       /v1/accounts/portfolio [post]
+func (c *AccountsController) GetPortfolio() gin.HandlerFunc {
+	return newRequestHandler(c.service.GetPortfolio, c.logger)
+}
+
+// ConvertAddress godoc
+// @Summary      Проверка адреса и получение адресов аккаунта в других сетях
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @Param        address  query string true  "bech32 или 0x адрес"
+// @Param        prefixes query string false "префиксы сетей через запятую, по умолчанию все сети"
+// @Success      200 {object} apiResponse{result=account.ConvertAddressResponse}
+// @Router       /v1/accounts/address/convert [get]
+func (c *AccountsController) ConvertAddress(context *gin.Context) {
+	request := account.ConvertAddressInput{
+		Address: context.Query("address"),
+	}
+
+	for _, prefix := range strings.Split(context.Query("prefixes"), ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			request.Prefixes = append(request.Prefixes, prefix)
+		}
+	}
+
+	handleRequest(request, context, c.service.ConvertAddress)
+}
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"github.com/Mobile-Web3/backend/internal/domain/delivery"
+	"github.com/Mobile-Web3/backend/pkg/log"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminController struct {
+	logger          log.Logger
+	deliveryService *delivery.Service
+}
+
+func NewAdminController(logger log.Logger, deliveryService *delivery.Service) *AdminController {
+	return &AdminController{
+		logger:          logger,
+		deliveryService: deliveryService,
+	}
+}
+
+// GetDeliveries godoc
+// @Summary      Получение статуса доставки уведомлений по хэшу транзакции
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @Param        Authorization header string true "Bearer ADMIN_TOKEN"
+// @Param        txHash        path   string true "хэш транзакции"
+// @Success      200 {object} apiResponse{result=[]delivery.Delivery}
+// @Router       /v1/admin/deliveries/{txHash} [get]
+func (c *AdminController) GetDeliveries(context *gin.Context) {
+	request := delivery.DeliveriesInput{
+		TxHash: context.Param("txHash"),
+	}
+	handleRequest(request, context, c.deliveryService.GetDeliveries)
+}
+
+// GetDeadLetters godoc
+// @Summary      Получение уведомлений, которые не удалось доставить
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @Param        Authorization header string true "Bearer ADMIN_TOKEN"
+// @Success      200 {object} apiResponse{result=[]delivery.Delivery}
+// @Router       /v1/admin/dead-letters [get]
+func (c *AdminController) GetDeadLetters() gin.HandlerFunc {
+	return newEmptyHandler(c.deliveryService.GetDeadLetters)
+}
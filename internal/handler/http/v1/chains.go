@@ -41,13 +41,21 @@ func (c *ChainsController) GetChains() gin.HandlerFunc {
 // @Produce      json
 // @Content-Type application/json
 // @Param        id     path  string true  "chainId"
-// @Param        limit  query int    false "кол-во валидаторов для запроса"
+// @Param        limit  query int    false "кол-во валидаторов для запроса, 10 по умолчанию, не больше 100"
 // @Param        offset query int    false "кол-во валидаторов для пропуска"
+// @Param        status query string false "статус: bonded (по умолчанию), unbonding, unbonded, all"
+// @Param        sort   query string false "сортировка: voting_power (по умолчанию), commission, name, uptime"
+// @Param        order  query string false "порядок сортировки: asc, desc"
+// @Param        search query string false "поиск по имени валидатора"
 // @Success      200 {object} apiResponse{result=chain.PagedValidatorsResponse}
 // @Router       /v1/chains/{id}/validators [get]
 func (c *ChainsController) GetPagedValidators(context *gin.Context) {
 	request := chain.PagedValidatorsInput{
 		ChainID: context.Param("id"),
+		Status:  context.Query("status"),
+		Sort:    context.Query("sort"),
+		Desc:    context.Query("order") == "desc",
+		Search:  context.Query("search"),
 	}
 
 	limit, _ := strconv.ParseUint(context.Query("limit"), 0, 64)
@@ -64,3 +72,57 @@ func (c *ChainsController) GetPagedValidators(context *gin.Context) {
 	request.Offset = offset
 	handleRequest(request, context, c.service.GetPagedValidators)
 }
+
+// GetValidator godoc
+// @Summary      Получение подробных данных о валидаторе
+// @Tags         chains
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @Param        id        path  string true  "chainId"
+// @Param        address   path  string true  "адрес валидатора"
+// @Param        delegator query string false "адрес делегатора для получения его делегации"
+// @Success      200 {object} apiResponse{result=chain.ValidatorDetails}
+// @Router       /v1/chains/{id}/validators/{address} [get]
+func (c *ChainsController) GetValidator(context *gin.Context) {
+	request := chain.ValidatorInput{
+		ChainID:   context.Param("id"),
+		Address:   context.Param("address"),
+		Delegator: context.Query("delegator"),
+	}
+	handleRequest(request, context, c.service.GetValidator)
+}
+
+// GetStaking godoc
+// @Summary      Получение параметров стейкинга и apr сети и валидаторов
+// @Tags         chains
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @Param        id path string true "chainId"
+// @Success      200 {object} apiResponse{result=chain.StakingResponse}
+// @Router       /v1/chains/{id}/staking [get]
+func (c *ChainsController) GetStaking(context *gin.Context) {
+	request := chain.StakingInput{
+		ChainID: context.Param("id"),
+	}
+
+	handleRequest(request, context, c.service.GetStaking)
+}
+
+// GetEndpoints godoc
+// @Summary      Получение состояния rpc эндпоинтов сети
+// @Tags         chains
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @Param        id path string true "chainId"
+// @Success      200 {object} apiResponse{result=chain.EndpointsHealth}
+// @Router       /v1/chains/{id}/endpoints [get]
+func (c *ChainsController) GetEndpoints(context *gin.Context) {
+	request := chain.EndpointsInput{
+		ChainID: context.Param("id"),
+	}
+
+	handleRequest(request, context, c.service.GetEndpointsHealth)
+}
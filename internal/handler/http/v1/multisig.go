@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"github.com/Mobile-Web3/backend/internal/domain/multisig"
+	"github.com/Mobile-Web3/backend/pkg/log"
+	"github.com/gin-gonic/gin"
+)
+
+type MultisigController struct {
+	logger  log.Logger
+	service *multisig.Service
+}
+
+func NewMultisigController(logger log.Logger, service *multisig.Service) *MultisigController {
+	return &MultisigController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+// CreateAccount godoc
+// @Summary      Создание мультисиг аккаунта по публичным ключам
+// @Tags         multisig
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @param        request body multisig.CreateAccountInput true "body"
+// @Success      200 {object} apiResponse{result=multisig.AccountResponse}
+// @Router       /v1/multisig/create [post]
+func (c *MultisigController) CreateAccount() gin.HandlerFunc {
+	return newRequestHandler(c.service.CreateAccount, c.logger)
+}
+
+// CreateTransaction godoc
+// @Summary      Создание неподписанной транзакции с мультисиг аккаунта
+// @Tags         multisig
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @param        request body multisig.CreateTxInput true "body"
+// @Success      200 {object} apiResponse{result=multisig.CreateTxResponse}
+// @Router       /v1/multisig/tx/create [post]
+func (c *MultisigController) CreateTransaction() gin.HandlerFunc {
+	return newRequestHandler(c.service.CreateTransaction, c.logger)
+}
+
+// SignTransaction godoc
+// @Summary      Частичная подпись транзакции участником мультисига
+// @Tags         multisig
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @param        request body multisig.SignTxInput true "body"
+// @Success      200 {object} apiResponse{result=multisig.Signature}
+// @Router       /v1/multisig/tx/sign [post]
+func (c *MultisigController) SignTransaction() gin.HandlerFunc {
+	return newRequestHandler(c.service.SignTransaction, c.logger)
+}
+
+// CombineTransaction godoc
+// @Summary      Сборка транзакции из частичных подписей
+// @Tags         multisig
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @param        request body multisig.CombineTxInput true "body"
+// @Success      200 {object} apiResponse{result=multisig.CombineTxResponse}
+// @Router       /v1/multisig/tx/combine [post]
+func (c *MultisigController) CombineTransaction() gin.HandlerFunc {
+	return newRequestHandler(c.service.CombineTransaction, c.logger)
+}
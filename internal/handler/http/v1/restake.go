@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"github.com/Mobile-Web3/backend/internal/domain/restake"
+	"github.com/Mobile-Web3/backend/pkg/log"
+	"github.com/gin-gonic/gin"
+)
+
+type RestakeController struct {
+	logger  log.Logger
+	service *restake.Service
+}
+
+func NewRestakeController(logger log.Logger, service *restake.Service) *RestakeController {
+	return &RestakeController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+// Grant godoc
+// @Summary      Разрешить автоматическое реинвестирование наград через authz
+// @Tags         restake
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @param        request body restake.GrantInput true "body"
+// @Success      200 {object} apiResponse{result=restake.GrantResponse}
+// @Router       /v1/restake/grant [post]
+func (c *RestakeController) Grant() gin.HandlerFunc {
+	return newRequestHandler(c.service.Grant, c.logger)
+}
+
+// Revoke godoc
+// @Summary      Отозвать разрешение на автоматическое реинвестирование наград
+// @Tags         restake
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @param        request body restake.RevokeInput true "body"
+// @Success      200 {object} apiResponse{result=restake.RevokeResponse}
+// @Router       /v1/restake/revoke [post]
+func (c *RestakeController) Revoke() gin.HandlerFunc {
+	return newRequestHandler(c.service.Revoke, c.logger)
+}
+
+// GetRuns godoc
+// @Summary      Получение истории реинвестирования наград делегатора
+// @Tags         restake
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @Param        chainId path string true "chainId"
+// @Param        address path string true "адрес делегатора"
+// @Success      200 {object} apiResponse{result=[]restake.Run}
+// @Router       /v1/restake/{chainId}/{address}/runs [get]
+func (c *RestakeController) GetRuns(context *gin.Context) {
+	request := restake.RunsInput{
+		ChainID: context.Param("chainId"),
+		Address: context.Param("address"),
+	}
+	handleRequest(request, context, c.service.GetRuns)
+}
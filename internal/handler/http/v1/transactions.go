@@ -1,20 +1,79 @@
 package v1
 
 import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
 	"github.com/Mobile-Web3/backend/internal/domain/transaction"
+	"github.com/Mobile-Web3/backend/internal/metrics"
 	"github.com/Mobile-Web3/backend/pkg/log"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// maxStreamsPerChain is a cap of concurrent tx event streams of the chain, every stream holds tx subscription.
+	maxStreamsPerChain = 500
+	// maxStreamsPerClient is a cap of concurrent tx event streams opened from the same ip.
+	maxStreamsPerClient = 10
+)
+
+var ErrTooManyStreams = errors.New("too many open event streams")
+
+// streamLimiter counts open event streams per chain and per client ip.
+type streamLimiter struct {
+	mutex   sync.Mutex
+	chains  map[string]int
+	clients map[string]int
+}
+
+func newStreamLimiter() *streamLimiter {
+	return &streamLimiter{
+		chains:  make(map[string]int),
+		clients: make(map[string]int),
+	}
+}
+
+// acquire registers the stream, returned function must be called when the stream is closed.
+func (l *streamLimiter) acquire(chainID string, clientIP string) (func(), error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.chains[chainID] >= maxStreamsPerChain || l.clients[clientIP] >= maxStreamsPerClient {
+		return nil, ErrTooManyStreams
+	}
+
+	l.chains[chainID]++
+	l.clients[clientIP]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+
+			if l.chains[chainID]--; l.chains[chainID] <= 0 {
+				delete(l.chains, chainID)
+			}
+			if l.clients[clientIP]--; l.clients[clientIP] <= 0 {
+				delete(l.clients, clientIP)
+			}
+		})
+	}, nil
+}
+
 type TransactionsController struct {
 	logger  log.Logger
 	service *transaction.Service
+	streams *streamLimiter
 }
 
 func NewTransactionsController(logger log.Logger, service *transaction.Service) *TransactionsController {
 	return &TransactionsController{
 		logger:  logger,
 		service: service,
+		streams: newStreamLimiter(),
 	}
 }
 
@@ -32,7 +91,7 @@ func (c *TransactionsController) SendTransaction() gin.HandlerFunc {
 }
 
 // SendTransactionFirebase godoc
-// @Summary      Отправить транзакцию с подпиской на события тендерминта с пушами в firebase
+// @Summary      Отправить транзакцию с подпиской на события тендерминта и уведомлением (fcm, webhook, apns)
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
@@ -56,3 +115,50 @@ func (c *TransactionsController) SendTransactionFirebase() gin.HandlerFunc {
 func (c *TransactionsController) SimulateTransaction() gin.HandlerFunc {
 	return newRequestHandler(c.service.SimulateTransaction, c.logger)
 }
+
+// sseKeepAliveInterval is a period of comments sent to keep idle event stream open through proxies.
+const sseKeepAliveInterval = time.Second * 15
+
+// StreamTransactionEvents godoc
+// @Summary      Поток событий статуса транзакции (SSE): broadcast, mempool, included, success/failure/timeout
+// @Tags         transactions
+// @Produce      text/event-stream
+// @Param        chainId path string true "chainId"
+// @Param        hash    path string true "хэш транзакции"
+// @Success      200 {object} transaction.TxStatusEvent
+// @Router       /v1/transactions/{chainId}/{hash}/events [get]
+func (c *TransactionsController) StreamTransactionEvents(context *gin.Context) {
+	release, err := c.streams.acquire(context.Param("chainId"), context.ClientIP())
+	if err != nil {
+		metrics.ErrorsCounter.Incr(1)
+		ErrorResponse(context, err)
+		return
+	}
+	defer release()
+
+	events, err := c.service.StreamTxEvents(context.Request.Context(), transaction.TxEventsInput{
+		ChainID: context.Param("chainId"),
+		TxHash:  context.Param("hash"),
+	})
+	if err != nil {
+		metrics.ErrorsCounter.Incr(1)
+		ErrorResponse(context, err)
+		return
+	}
+
+	context.Header("Cache-Control", "no-cache")
+	context.Header("Connection", "keep-alive")
+	context.Header("X-Accel-Buffering", "no")
+	context.Stream(func(writer io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			context.SSEvent(string(event.Stage), event)
+		case <-time.After(sseKeepAliveInterval):
+			_, _ = writer.Write([]byte(": keep-alive\n\n"))
+		}
+		return true
+	})
+}
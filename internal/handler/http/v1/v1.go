@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/Mobile-Web3/backend/internal/metrics"
 	"github.com/Mobile-Web3/backend/pkg/log"
@@ -11,11 +12,21 @@ import (
 )
 
 func MetricsHandler(context *gin.Context) {
+	var sb strings.Builder
+	healthy, total := 0, 0
+	for _, count := range metrics.Endpoints.Get() {
+		healthy += count.Healthy
+		total += count.Total
+		sb.WriteString(fmt.Sprintf("\n<div>%s: %d/%d</div>", count.ChainID, count.Healthy, count.Total))
+	}
+
 	result := fmt.Sprintf(`<h3>Metrics</h3>
 <div>average requests   per second for the last hour: %d</div>
 <div>average errors     per second for the last hour: %d</div>
-<div>average exceptions per second for the last hour: %d</div>`,
-		metrics.RpsCounter.Rate()/60/60, metrics.ErrorsCounter.Rate()/60/60, metrics.PanicsCounter.Rate()/60/60)
+<div>average exceptions per second for the last hour: %d</div>
+<h3>Healthy rpc endpoints: %d/%d</h3>%s`,
+		metrics.RpsCounter.Rate()/60/60, metrics.ErrorsCounter.Rate()/60/60, metrics.PanicsCounter.Rate()/60/60,
+		healthy, total, sb.String())
 	context.Writer.WriteHeader(http.StatusOK)
 	context.Writer.Header().Set("Content-Type", "text/html")
 	_, err := context.Writer.Write([]byte(result))
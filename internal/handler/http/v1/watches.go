@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"github.com/Mobile-Web3/backend/internal/domain/watch"
+	"github.com/Mobile-Web3/backend/internal/metrics"
+	"github.com/Mobile-Web3/backend/pkg/log"
+	"github.com/gin-gonic/gin"
+)
+
+type WatchesController struct {
+	logger  log.Logger
+	service *watch.Service
+}
+
+func NewWatchesController(logger log.Logger, service *watch.Service) *WatchesController {
+	return &WatchesController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+// CreateWatch godoc
+// @Summary      Подписаться на уведомления по адресу: входящие переводы, валидаторы, голосования
+// @Tags         watches
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @param        request body watch.CreateInput true "body"
+// @Success      200 {object} apiResponse{result=watch.Watch}
+// @Router       /v1/watches [post]
+func (c *WatchesController) CreateWatch() gin.HandlerFunc {
+	return newRequestHandler(c.service.Create, c.logger)
+}
+
+// RemoveWatch godoc
+// @Summary      Отписаться от уведомлений по адресу
+// @Tags         watches
+// @Accept       json
+// @Produce      json
+// @Content-Type application/json
+// @Param        id path string true "id подписки"
+// @param        request body watch.RemoveInput true "получатель уведомлений, создавший подписку"
+// @Success      200 {object} apiResponse{result=watch.Watch}
+// @Router       /v1/watches/{id} [delete]
+func (c *WatchesController) RemoveWatch(context *gin.Context) {
+	var request watch.RemoveInput
+	if err := context.BindJSON(&request); err != nil {
+		c.logger.Error(err)
+		metrics.ErrorsCounter.Incr(1)
+		ErrorResponse(context, err)
+		return
+	}
+
+	request.ID = context.Param("id")
+	handleRequest(request, context, c.service.Remove)
+}
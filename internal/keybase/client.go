@@ -0,0 +1,81 @@
+package keybase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const DefaultURL = "https://keybase.io/_/api/1.0"
+
+type lookupResponse struct {
+	Status struct {
+		Code int    `json:"code"`
+		Desc string `json:"desc"`
+	} `json:"status"`
+	Them []*struct {
+		Pictures struct {
+			Primary struct {
+				URL string `json:"url"`
+			} `json:"primary"`
+		} `json:"pictures"`
+	} `json:"them"`
+}
+
+// AvatarClient resolves validator identity (keybase key suffix) to keybase profile picture url.
+type AvatarClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewAvatarClient(baseURL string) *AvatarClient {
+	return &AvatarClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+}
+
+func (c *AvatarClient) GetAvatarURL(ctx context.Context, identity string) (string, error) {
+	query := url.Values{}
+	query.Set("key_suffix", identity)
+	query.Set("fields", "pictures")
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/user/lookup.json?%s", c.baseURL, query.Encode()), nil)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("keybase respond with status code: %d", response.StatusCode)
+	}
+
+	var result lookupResponse
+	if err = json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	if result.Status.Code != 0 {
+		return "", fmt.Errorf("keybase respond with status: %d %s", result.Status.Code, result.Status.Desc)
+	}
+
+	// unknown identity is returned as empty list, user without picture has empty url
+	for _, user := range result.Them {
+		if user != nil && user.Pictures.Primary.URL != "" {
+			return user.Pictures.Primary.URL, nil
+		}
+	}
+
+	return "", nil
+}
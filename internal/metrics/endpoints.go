@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+var Endpoints = &EndpointsGauge{
+	chains: make(map[string]EndpointsCount),
+}
+
+type EndpointsCount struct {
+	ChainID string
+	Healthy int
+	Total   int
+}
+
+// EndpointsGauge holds count of healthy rpc endpoints per chain from the last health check.
+type EndpointsGauge struct {
+	mutex  sync.RWMutex
+	chains map[string]EndpointsCount
+}
+
+func (g *EndpointsGauge) Set(chainID string, healthy int, total int) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.chains[chainID] = EndpointsCount{
+		ChainID: chainID,
+		Healthy: healthy,
+		Total:   total,
+	}
+}
+
+func (g *EndpointsGauge) Get() []EndpointsCount {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	result := make([]EndpointsCount, 0, len(g.chains))
+	for _, count := range g.chains {
+		result = append(result, count)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ChainID < result[j].ChainID
+	})
+	return result
+}
@@ -0,0 +1,121 @@
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// secretSize is a count of random bytes of webhook secret.
+const secretSize = 32
+
+type Channel string
+
+const (
+	ChannelFCM     Channel = "fcm"
+	ChannelWebhook Channel = "webhook"
+	ChannelAPNs    Channel = "apns"
+)
+
+var (
+	ErrUnknownChannel = errors.New("unknown notification channel")
+	// ErrInvalidTarget means that the target will never accept notifications, e.g. push token is expired.
+	ErrInvalidTarget = errors.New("invalid notification target")
+	// ErrRejected means that notification is rejected and retrying it is useless.
+	ErrRejected = errors.New("notification rejected")
+)
+
+// Target is a recipient of notifications, token is used by push channels and url by webhook.
+// Locale selects language of notification texts, e.g. "en" or "ru".
+type Target struct {
+	Channel Channel `json:"channel"`
+	Token   string  `json:"token,omitempty"`
+	URL     string  `json:"url,omitempty"`
+	Locale  string  `json:"locale,omitempty"`
+	// Secret signs webhook deliveries, it is generated at registration and returned to the registrant only.
+	Secret string `json:"secret,omitempty"`
+}
+
+// WithSecret returns webhook target with the new random secret, secret of other channels is cleared.
+func (t Target) WithSecret() (Target, error) {
+	t.Secret = ""
+	if t.Channel != ChannelWebhook {
+		return t, nil
+	}
+
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return Target{}, err
+	}
+
+	t.Secret = hex.EncodeToString(secret)
+	return t, nil
+}
+
+// Public returns the target without secret, so it could be shown to anybody knowing the target.
+func (t Target) Public() Target {
+	t.Secret = ""
+	return t
+}
+
+// Same reports whether both targets are the same recipient regardless of their locale.
+func (t Target) Same(other Target) bool {
+	return t.Channel == other.Channel && t.Token == other.Token && t.URL == other.URL
+}
+
+func (t Target) Validate() error {
+	switch t.Channel {
+	case ChannelFCM, ChannelAPNs:
+		if t.Token == "" {
+			return fmt.Errorf("invalid %s token", t.Channel)
+		}
+	case ChannelWebhook:
+		// webhook is accepted only over tls, private addresses are rejected by webhook client when connecting
+		u, err := url.Parse(t.URL)
+		if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+			return fmt.Errorf("invalid webhook url")
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownChannel, t.Channel)
+	}
+
+	return nil
+}
+
+type Message struct {
+	Type  string            `json:"type"`
+	Title string            `json:"title,omitempty"`
+	Body  string            `json:"body,omitempty"`
+	Data  map[string]string `json:"data"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, target Target, message Message) error
+}
+
+// Router sends notification with the notifier of target channel, texts are localized for the target.
+type Router struct {
+	notifiers map[Channel]Notifier
+}
+
+func NewRouter() *Router {
+	return &Router{
+		notifiers: make(map[Channel]Notifier),
+	}
+}
+
+func (r *Router) Register(channel Channel, notifier Notifier) {
+	r.notifiers[channel] = notifier
+}
+
+func (r *Router) Notify(ctx context.Context, target Target, message Message) error {
+	notifier, ok := r.notifiers[target.Channel]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownChannel, target.Channel)
+	}
+
+	return notifier.Notify(ctx, target, Localize(target.Locale, message))
+}
@@ -0,0 +1,215 @@
+package notify
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+type Locale string
+
+const (
+	LocaleEN Locale = "en"
+	LocaleRU Locale = "ru"
+
+	DefaultLocale = LocaleEN
+)
+
+// ParseLocale returns supported locale by language tag, e.g. "ru-RU", default locale is used for unknown ones.
+func ParseLocale(value string) Locale {
+	language := strings.ToLower(value)
+	if index := strings.IndexAny(language, "-_"); index >= 0 {
+		language = language[:index]
+	}
+
+	switch Locale(language) {
+	case LocaleEN, LocaleRU:
+		return Locale(language)
+	default:
+		return DefaultLocale
+	}
+}
+
+// messageTemplate is a title and body of the message type, templates are executed with message data.
+type messageTemplate struct {
+	title string
+	body  string
+}
+
+// messageTemplates are selected by "<type>.<status>" key first and by message type then.
+var messageTemplates = map[Locale]map[string]messageTemplate{
+	LocaleEN: {
+		"tx_result.success": {
+			title: "Transaction confirmed",
+			body:  "{{.amount}} sent to {{short .recipient}} on {{.chainName}}",
+		},
+		"tx_result.failure": {
+			title: "Transaction failed",
+			body:  "Sending {{.amount}} to {{short .recipient}} on {{.chainName}} failed: {{reason .reason}}",
+		},
+		"tx_result.timeout": {
+			title: "Transaction is not confirmed",
+			body:  "Sending {{.amount}} to {{short .recipient}} on {{.chainName}} is not confirmed in time",
+		},
+		"transfer_received": {
+			title: "Received {{.amount}}",
+			body:  "You received {{.amount}} to {{short .recipient}} on {{.chainName}}",
+		},
+		"validator_alert.jailed": {
+			title: "Validator jailed",
+			body:  "{{.validator}} on {{.chainName}} is jailed, your delegation does not earn rewards",
+		},
+		"validator_alert.tombstoned": {
+			title: "Validator tombstoned",
+			body:  "{{.validator}} on {{.chainName}} is tombstoned for double signing and will not return, redelegate your tokens",
+		},
+		"validator_alert.inactive": {
+			title: "Validator left active set",
+			body:  "{{.validator}} on {{.chainName}} left the active set, your delegation does not earn rewards",
+		},
+		"validator_alert.commission": {
+			title: "Validator commission increased",
+			body:  "{{.validator}} on {{.chainName}} increased commission from {{.oldCommission}} to {{.newCommission}}",
+		},
+		"proposal_alert.voting_started": {
+			title: "Voting started on {{.chainName}}",
+			body:  "Proposal #{{.proposalId}}{{if .title}} «{{.title}}»{{end}} is open for voting",
+		},
+		"proposal_alert.voting_ending": {
+			title: "Voting ends soon on {{.chainName}}",
+			body:  "You have not voted on proposal #{{.proposalId}}{{if .title}} «{{.title}}»{{end}}, voting ends soon",
+		},
+	},
+	LocaleRU: {
+		"tx_result.success": {
+			title: "Транзакция подтверждена",
+			body:  "{{.amount}} отправлено на {{short .recipient}} в сети {{.chainName}}",
+		},
+		"tx_result.failure": {
+			title: "Транзакция не выполнена",
+			body:  "Отправка {{.amount}} на {{short .recipient}} в сети {{.chainName}} не выполнена: {{reason .reason}}",
+		},
+		"tx_result.timeout": {
+			title: "Транзакция не подтверждена",
+			body:  "Отправка {{.amount}} на {{short .recipient}} в сети {{.chainName}} не подтверждена вовремя",
+		},
+		"transfer_received": {
+			title: "Получено {{.amount}}",
+			body:  "Вы получили {{.amount}} на {{short .recipient}} в сети {{.chainName}}",
+		},
+		"validator_alert.jailed": {
+			title: "Валидатор заблокирован",
+			body:  "{{.validator}} в сети {{.chainName}} заблокирован, ваш стейкинг не приносит награды",
+		},
+		"validator_alert.tombstoned": {
+			title: "Валидатор исключен навсегда",
+			body:  "{{.validator}} в сети {{.chainName}} исключен навсегда за двойную подпись, переделегируйте ваши токены",
+		},
+		"validator_alert.inactive": {
+			title: "Валидатор вышел из активного сета",
+			body:  "{{.validator}} в сети {{.chainName}} вышел из активного сета, ваш стейкинг не приносит награды",
+		},
+		"validator_alert.commission": {
+			title: "Комиссия валидатора повышена",
+			body:  "{{.validator}} в сети {{.chainName}} повысил комиссию с {{.oldCommission}} до {{.newCommission}}",
+		},
+		"proposal_alert.voting_started": {
+			title: "Началось голосование в сети {{.chainName}}",
+			body:  "Открыто голосование по предложению #{{.proposalId}}{{if .title}} «{{.title}}»{{end}}",
+		},
+		"proposal_alert.voting_ending": {
+			title: "Голосование в сети {{.chainName}} скоро закончится",
+			body:  "Вы не проголосовали по предложению #{{.proposalId}}{{if .title}} «{{.title}}»{{end}}, голосование скоро закончится",
+		},
+	},
+}
+
+// failureReasons are readable texts of cosmos.FailureReason values.
+var failureReasons = map[Locale]map[string]string{
+	LocaleEN: {
+		"insufficient_funds": "insufficient funds",
+		"insufficient_fee":   "insufficient fee",
+		"out_of_gas":         "out of gas",
+		"sequence_mismatch":  "account sequence mismatch, try again",
+		"unauthorized":       "signature verification failed",
+		"invalid_address":    "invalid address",
+		"invalid_coins":      "invalid amount",
+		"memo_too_large":     "memo is too large",
+		"tx_timeout":         "transaction has expired",
+		"unknown":            "unknown error",
+	},
+	LocaleRU: {
+		"insufficient_funds": "недостаточно средств",
+		"insufficient_fee":   "недостаточная комиссия",
+		"out_of_gas":         "недостаточно газа",
+		"sequence_mismatch":  "неверный номер транзакции аккаунта, попробуйте снова",
+		"unauthorized":       "неверная подпись",
+		"invalid_address":    "неверный адрес",
+		"invalid_coins":      "неверная сумма",
+		"memo_too_large":     "слишком длинный комментарий",
+		"tx_timeout":         "время транзакции истекло",
+		"unknown":            "неизвестная ошибка",
+	},
+}
+
+func shortAddress(address string) string {
+	if len(address) <= 16 {
+		return address
+	}
+
+	return address[:10] + "…" + address[len(address)-6:]
+}
+
+func execute(locale Locale, text string, data map[string]string) (string, error) {
+	funcs := template.FuncMap{
+		"short": shortAddress,
+		"reason": func(reason string) string {
+			if result, ok := failureReasons[locale][reason]; ok {
+				return result
+			}
+			return failureReasons[locale]["unknown"]
+		},
+	}
+
+	tmpl, err := template.New("message").Funcs(funcs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var result bytes.Buffer
+	if err = tmpl.Execute(&result, data); err != nil {
+		return "", err
+	}
+
+	return result.String(), nil
+}
+
+// Localize fills title and body of the message from template of its type in the locale.
+// Messages with title or body, and messages without templates are returned as is.
+func Localize(locale string, message Message) Message {
+	if message.Title != "" || message.Body != "" {
+		return message
+	}
+
+	templates := messageTemplates[ParseLocale(locale)]
+	messageTemplate, ok := templates[message.Type+"."+message.Data["status"]]
+	if !ok {
+		if messageTemplate, ok = templates[message.Type]; !ok {
+			return message
+		}
+	}
+
+	title, err := execute(ParseLocale(locale), messageTemplate.title, message.Data)
+	if err != nil {
+		return message
+	}
+
+	body, err := execute(ParseLocale(locale), messageTemplate.body, message.Data)
+	if err != nil {
+		return message
+	}
+
+	message.Title = title
+	message.Body = body
+	return message
+}
@@ -0,0 +1,184 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/Mobile-Web3/backend/internal/notify"
+	"github.com/Mobile-Web3/backend/pkg/log"
+)
+
+const (
+	SignatureHeader = "X-Signature"
+	TimestampHeader = "X-Timestamp"
+	// KeyIDHeader identifies the secret of the signature, when receiver has registered several webhooks.
+	KeyIDHeader = "X-Signature-Key-Id"
+
+	maxRedirects = 3
+)
+
+var (
+	errForbiddenAddress = errors.New("webhook address is not public")
+	errInsecureRedirect = errors.New("webhook redirect is not https")
+	errMissingSecret    = errors.New("webhook target has no secret")
+
+	// sharedAddressSpace is carrier-grade NAT range, which is not routed in internet.
+	sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+)
+
+// isPublicIP reports whether the address is routed in internet, so webhook can't reach internal services.
+func isPublicIP(ip net.IP) bool {
+	return ip != nil &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// controlAddress checks resolved address right before connecting, so host resolved to other address after validation
+// (DNS rebinding) is rejected as well.
+func controlAddress(network string, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !isPublicIP(net.ParseIP(host)) {
+		return fmt.Errorf("%w: %s", errForbiddenAddress, host)
+	}
+
+	return nil
+}
+
+func checkRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	if request.URL.Scheme != "https" {
+		return errInsecureRedirect
+	}
+
+	return nil
+}
+
+// Client posts notifications to webhook urls. Receivers check the signature, which is
+// hex encoded HMAC-SHA256 of "<timestamp>.<body>" with the secret returned at webhook registration.
+type Client struct {
+	logger log.Logger
+	client *http.Client
+}
+
+func NewClient(logger log.Logger) *Client {
+	return &Client{
+		logger: logger,
+		client: &http.Client{
+			Timeout: time.Second * 10,
+			// proxy is not used, so the dialer connects to webhook host itself
+			Transport: &http.Transport{
+				DialContext: (&net.Dialer{
+					Timeout: time.Second * 5,
+					Control: controlAddress,
+				}).DialContext,
+				ForceAttemptHTTP2:   true,
+				TLSHandshakeTimeout: time.Second * 5,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     time.Second * 90,
+			},
+			CheckRedirect: checkRedirect,
+		},
+	}
+}
+
+type payload struct {
+	notify.Message
+	SentAt time.Time `json:"sentAt"`
+}
+
+func sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// keyID is a short fingerprint of the secret, which doesn't disclose it.
+func keyID(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:8])
+}
+
+func (c *Client) Notify(ctx context.Context, target notify.Target, message notify.Message) error {
+	if target.Secret == "" {
+		err := fmt.Errorf("webhook; %w: %s", notify.ErrInvalidTarget, errMissingSecret.Error())
+		c.logger.Error(err)
+		return err
+	}
+
+	now := time.Now()
+	body, err := json.Marshal(payload{
+		Message: message,
+		SentAt:  now.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(TimestampHeader, timestamp)
+	request.Header.Set(KeyIDHeader, keyID(target.Secret))
+	request.Header.Set(SignatureHeader, sign(target.Secret, timestamp, body))
+
+	response, err := c.client.Do(request)
+	if errors.Is(err, errForbiddenAddress) || errors.Is(err, errInsecureRedirect) {
+		err = fmt.Errorf("webhook; %w: %s", notify.ErrRejected, err.Error())
+		c.logger.Error(err)
+		return err
+	}
+	if err != nil {
+		err = fmt.Errorf("webhook; %s", err.Error())
+		c.logger.Error(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusGone:
+		err = fmt.Errorf("webhook; %w: respond with status code: %d", notify.ErrInvalidTarget, response.StatusCode)
+		c.logger.Error(err)
+		return err
+	case response.StatusCode >= http.StatusBadRequest && response.StatusCode < http.StatusInternalServerError &&
+		response.StatusCode != http.StatusRequestTimeout && response.StatusCode != http.StatusTooManyRequests:
+		err = fmt.Errorf("webhook; %w: respond with status code: %d", notify.ErrRejected, response.StatusCode)
+		c.logger.Error(err)
+		return err
+	case response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices:
+		err = fmt.Errorf("webhook respond with status code: %d", response.StatusCode)
+		c.logger.Error(err)
+		return err
+	}
+
+	return nil
+}
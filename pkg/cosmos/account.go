@@ -2,13 +2,16 @@ package cosmos
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	"github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"github.com/cosmos/go-bip39"
 	ethhd "github.com/evmos/ethermint/crypto/hd"
 )
@@ -63,6 +66,35 @@ func (c *Client) CreateAccountFromHexKey(key string) (types.PrivKey, error) {
 	}, nil
 }
 
+var ErrInvalidAddress = errors.New("invalid address")
+
+// ParseAddress validates bech32 or hex (0x) address and returns its prefix and bytes.
+// Prefix is empty for hex addresses.
+func (c *Client) ParseAddress(address string) (string, types.Address, error) {
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		addressBytes, err := hex.DecodeString(address[2:])
+		if err != nil || len(addressBytes) != 20 {
+			err = fmt.Errorf("%w: %s; expected 20 bytes hex string", ErrInvalidAddress, address)
+			return "", nil, err
+		}
+
+		return "", addressBytes, nil
+	}
+
+	prefix, addressBytes, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		err = fmt.Errorf("%w: %s; %s", ErrInvalidAddress, address, err.Error())
+		return "", nil, err
+	}
+
+	if err = sdk.VerifyAddressFormat(addressBytes); err != nil {
+		err = fmt.Errorf("%w: %s; %s", ErrInvalidAddress, address, err.Error())
+		return "", nil, err
+	}
+
+	return prefix, addressBytes, nil
+}
+
 func (c *Client) ConvertAddressPrefix(chainPrefix string, address types.Address) (string, error) {
 	result, err := sdk.Bech32ifyAddressBytes(chainPrefix, address)
 	if err != nil {
@@ -0,0 +1,122 @@
+package cosmos
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/x/auth/migrations/legacytx"
+)
+
+var ErrSignerMismatch = errors.New("signer address does not match public key")
+
+// msgSignData is the ADR-036 message type for off-chain signing of arbitrary data.
+type msgSignData struct {
+	Signer string `json:"signer"`
+	Data   []byte `json:"data"`
+}
+
+type arbitrarySignDoc struct {
+	AccountNumber uint64            `json:"account_number"`
+	ChainID       string            `json:"chain_id"`
+	Fee           json.RawMessage   `json:"fee"`
+	Memo          string            `json:"memo"`
+	Msgs          []json.RawMessage `json:"msgs"`
+	Sequence      uint64            `json:"sequence"`
+}
+
+// getArbitrarySignBytes returns ADR-036 sign bytes: amino json sign doc with empty chain id,
+// zero account number, sequence and fee and the only sign/MsgSignData message.
+func (c *Client) getArbitrarySignBytes(signer string, data []byte) ([]byte, error) {
+	msg, err := c.amino.MarshalJSON(msgSignData{
+		Signer: signer,
+		Data:   data,
+	})
+	if err != nil {
+		err = fmt.Errorf("marshal sign data message; %s", err.Error())
+		return nil, err
+	}
+
+	doc, err := c.amino.MarshalJSON(arbitrarySignDoc{
+		Fee:  legacytx.StdFee{Amount: sdk.NewCoins()}.Bytes(),
+		Msgs: []json.RawMessage{msg},
+	})
+	if err != nil {
+		err = fmt.Errorf("marshal arbitrary sign doc; %s", err.Error())
+		return nil, err
+	}
+
+	return sdk.SortJSON(doc)
+}
+
+func (c *Client) SignArbitrary(key types.PrivKey, signer string, data []byte) ([]byte, error) {
+	if err := checkSigner(key.PubKey(), signer); err != nil {
+		return nil, err
+	}
+
+	signBytes, err := c.getArbitrarySignBytes(signer, data)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := key.Sign(signBytes)
+	if err != nil {
+		err = fmt.Errorf("sign arbitrary data; %s", err.Error())
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+func (c *Client) VerifyArbitrary(pubKey types.PubKey, signer string, data []byte, signature []byte) (bool, error) {
+	if err := checkSigner(pubKey, signer); err != nil {
+		return false, err
+	}
+
+	signBytes, err := c.getArbitrarySignBytes(signer, data)
+	if err != nil {
+		return false, err
+	}
+
+	return pubKey.VerifySignature(signBytes, signature), nil
+}
+
+func checkSigner(pubKey types.PubKey, signer string) error {
+	_, address, err := bech32.DecodeAndConvert(signer)
+	if err != nil {
+		err = fmt.Errorf("decoding signer address %s; %s", signer, err.Error())
+		return err
+	}
+
+	if !bytes.Equal(address, pubKey.Address()) {
+		return ErrSignerMismatch
+	}
+
+	return nil
+}
+
+// MarshalPubKeyJSON returns amino json representation of public key, e.g.
+// {"type":"tendermint/PubKeySecp256k1","value":"..."}.
+func (c *Client) MarshalPubKeyJSON(pubKey types.PubKey) ([]byte, error) {
+	result, err := c.amino.MarshalJSON(pubKey)
+	if err != nil {
+		err = fmt.Errorf("marshal public key; %s", err.Error())
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) UnmarshalPubKeyJSON(data []byte) (types.PubKey, error) {
+	var pubKey types.PubKey
+	if err := c.amino.UnmarshalJSON(data, &pubKey); err != nil {
+		err = fmt.Errorf("unmarshal public key; %s", err.Error())
+		return nil, err
+	}
+
+	return pubKey, nil
+}
@@ -0,0 +1,32 @@
+package cosmos
+
+import (
+	"context"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// HasGrant reports whether granter has unexpired authorization of the message type for grantee.
+func (c *Client) HasGrant(ctx context.Context, chainID string, granter string, grantee string, msgTypeURL string) (bool, error) {
+	response, err := authz.NewQueryClient(c.GetChainGrpcClient(chainID)).Grants(ctx, &authz.QueryGrantsRequest{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgTypeUrl: msgTypeURL,
+	})
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	for _, grant := range response.Grants {
+		if grant.Expiration == nil || grant.Expiration.After(now) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
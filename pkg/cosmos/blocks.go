@@ -0,0 +1,37 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const year = time.Hour * 24 * 365
+
+var errNotEnoughBlocks = errors.New("not enough blocks to measure block time")
+
+// GetBlocksPerYear returns count of blocks per year measured by average time of the last blocks.
+func (c *Client) GetBlocksPerYear(ctx context.Context, chainID string, blocks int64) (uint64, error) {
+	client := c.GetChainHttpClient(chainID)
+	latest, err := client.Block(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	height := latest.Block.Height - blocks
+	if height < 1 {
+		return 0, errNotEnoughBlocks
+	}
+
+	previous, err := client.Block(ctx, &height)
+	if err != nil {
+		return 0, err
+	}
+
+	duration := latest.Block.Time.Sub(previous.Block.Time)
+	if duration <= 0 {
+		return 0, errNotEnoughBlocks
+	}
+
+	return uint64(float64(blocks) * float64(year) / float64(duration)), nil
+}
@@ -1,14 +1,17 @@
 package cosmos
 
 import (
+	"context"
 	"errors"
 	"sync"
 
 	"github.com/Mobile-Web3/backend/pkg/cosmos/connection"
+	"github.com/Mobile-Web3/backend/pkg/log"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	dbm "github.com/tendermint/tm-db"
 )
 
 var ErrSignModeUnknown = errors.New("unknown sign mode")
@@ -17,6 +20,7 @@ type chain struct {
 	ID              string
 	HttpClient      *connection.HttpClient
 	GrpcClient      *connection.GrpcClient
+	QueryClient     *connection.QueryClient
 	WebsocketClient *connection.WebsocketClient
 }
 
@@ -29,16 +33,25 @@ type Client struct {
 	mutex  sync.RWMutex
 	chains map[string]chain
 
+	lightDB        dbm.DB
+	verifiedChains map[string]connection.TrustedHeader
+	// rpcChains are chains, which queries are sent to rpc endpoints before grpc and rest ones.
+	rpcChains map[string]struct{}
+
 	signMode signing.SignMode
+	logger   log.Logger
 
 	getRpcHandler  connection.GetRpcHandler
-	txEventHandler connection.TxEventHandler
+	getGrpcHandler connection.GetRpcHandler
+	getRestHandler connection.GetRpcHandler
 }
 
 func NewClient(
+	logger log.Logger,
 	signMode string,
-	txEventHandler connection.TxEventHandler,
-	getRpcHandler connection.GetRpcHandler) (*Client, error) {
+	getRpcHandler connection.GetRpcHandler,
+	getGrpcHandler connection.GetRpcHandler,
+	getRestHandler connection.GetRpcHandler) (*Client, error) {
 	codecData := makeCodec()
 
 	mode := signing.SignMode_SIGN_MODE_UNSPECIFIED
@@ -60,10 +73,15 @@ func NewClient(
 		mutex:  sync.RWMutex{},
 		chains: make(map[string]chain),
 
+		verifiedChains: make(map[string]connection.TrustedHeader),
+		rpcChains:      make(map[string]struct{}),
+
 		signMode: mode,
+		logger:   logger,
 
-		txEventHandler: txEventHandler,
 		getRpcHandler:  getRpcHandler,
+		getGrpcHandler: getGrpcHandler,
+		getRestHandler: getRestHandler,
 	}, nil
 }
 
@@ -73,12 +91,32 @@ func (c *Client) initChainData(chainID string) chain {
 	chainData := chain{}
 	chainData.ID = chainID
 	chainData.HttpClient = connection.NewHttpClient(chainID, c.getRpcHandler)
-	chainData.GrpcClient = connection.NewGrpcClient(chainData.HttpClient, c.interfaceRegistry, c.codec)
-	chainData.WebsocketClient = connection.NewWebsocketClient(chainID, c.getRpcHandler, c.txEventHandler)
+	var lightClient *connection.LightClient
+	if trusted, ok := c.verifiedChains[chainID]; ok {
+		lightClient = connection.NewLightClient(chainID, trusted, c.getRpcHandler, c.lightDB)
+	}
+	chainData.GrpcClient = connection.NewGrpcClient(chainData.HttpClient, lightClient, c.interfaceRegistry, c.codec)
+	_, preferRPC := c.rpcChains[chainID]
+	chainData.QueryClient = connection.NewQueryClient(
+		connection.NewNativeGrpcClient(chainID, c.getGrpcHandler, c.interfaceRegistry),
+		chainData.HttpClient,
+		chainData.GrpcClient,
+		connection.NewRestClient(chainID, c.getRestHandler, c.interfaceRegistry, c.codec),
+		preferRPC)
+	chainData.WebsocketClient = connection.NewWebsocketClient(chainID, c.getRpcHandler, c.logger)
 	c.chains[chainID] = chainData
 	return chainData
 }
 
+// Stop closes websockets of all chains and cancels their subscriptions.
+func (c *Client) Stop() {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for _, chainData := range c.chains {
+		chainData.WebsocketClient.Stop()
+	}
+}
+
 func (c *Client) getChainData(chainID string) chain {
 	c.mutex.RLock()
 	chainData, ok := c.chains[chainID]
@@ -95,12 +133,30 @@ func (c *Client) GetChainHttpClient(chainID string) *connection.HttpClient {
 	return chainData.HttpClient
 }
 
-func (c *Client) GetChainGrpcClient(chainID string) *connection.GrpcClient {
+func (c *Client) GetChainGrpcClient(chainID string) *connection.QueryClient {
 	chainData := c.getChainData(chainID)
-	return chainData.GrpcClient
+	return chainData.QueryClient
 }
 
 func (c *Client) GetChainWebsocketClient(chainID string) *connection.WebsocketClient {
 	chainData := c.getChainData(chainID)
 	return chainData.WebsocketClient
 }
+
+// PreferRPC sends queries of the chains to rpc endpoints first, e.g. when rpc endpoints are overridden
+// by configuration, grpc and rest endpoints from registry are used only as fallback.
+func (c *Client) PreferRPC(chainIDs ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, chainID := range chainIDs {
+		c.rpcChains[chainID] = struct{}{}
+	}
+}
+
+// CheckEndpoints probes all rpc endpoints of the chain, re-ranks them and returns their status.
+func (c *Client) CheckEndpoints(ctx context.Context, chainID string) ([]connection.EndpointStatus, error) {
+	httpClient := c.GetChainHttpClient(chainID)
+	err := httpClient.Refresh(ctx)
+	return httpClient.Health(), err
+}
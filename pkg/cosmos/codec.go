@@ -23,6 +23,7 @@ func makeCodec() codecTypes {
 	std.RegisterInterfaces(encodingConfig.InterfaceRegistry)
 	modBasic.RegisterLegacyAminoCodec(encodingConfig.Amino)
 	modBasic.RegisterInterfaces(encodingConfig.InterfaceRegistry)
+	encodingConfig.Amino.RegisterConcrete(msgSignData{}, "sign/MsgSignData", nil)
 	return encodingConfig
 }
 
@@ -28,15 +28,19 @@ var (
 	ErrStreamingNotSupported = errors.New("streaming rpc not supported")
 )
 
+// GrpcClient tunnels grpc queries through abci queries over tendermint rpc.
+// Store queries with proof are verified when light client is set.
 type GrpcClient struct {
 	rpcClient         tendermint.ABCIClient
+	lightClient       *LightClient
 	interfaceRegistry types.InterfaceRegistry
 	codec             codec.Codec
 }
 
-func NewGrpcClient(rpcClient tendermint.ABCIClient, interfaceRegistry types.InterfaceRegistry, codec codec.Codec) *GrpcClient {
+func NewGrpcClient(rpcClient tendermint.ABCIClient, lightClient *LightClient, interfaceRegistry types.InterfaceRegistry, codec codec.Codec) *GrpcClient {
 	return &GrpcClient{
 		rpcClient:         rpcClient,
+		lightClient:       lightClient,
 		interfaceRegistry: interfaceRegistry,
 		codec:             codec,
 	}
@@ -90,17 +94,46 @@ func (c *GrpcClient) queryABCI(ctx context.Context, req abci.RequestQuery) (abci
 	}
 
 	if !result.Response.IsOK() {
-		err = fmt.Errorf("rpc error response; %s", sdkErrorToGRPCError(result.Response))
+		err = fmt.Errorf("rpc error response; %w", sdkErrorToGRPCError(result.Response))
 		return abci.ResponseQuery{}, err
 	}
 
-	if !opts.Prove || !isQueryStoreWithProof(req.Path) {
+	if !opts.Prove || !isQueryStoreWithProof(req.Path) || c.lightClient == nil {
 		return result.Response, nil
 	}
 
+	if err = c.lightClient.VerifyQuery(ctx, req.Path, req.Data, result.Response); err != nil {
+		return abci.ResponseQuery{}, err
+	}
+
 	return result.Response, nil
 }
 
+// QueryStore returns value of the key from module store verified by light client.
+// State of the previous block is queried, because its app hash is already committed.
+func (c *GrpcClient) QueryStore(ctx context.Context, storeName string, key []byte) ([]byte, int64, error) {
+	if c.lightClient == nil {
+		return nil, 0, ErrVerificationDisabled
+	}
+
+	info, err := c.rpcClient.ABCIInfo(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	response, err := c.queryABCI(ctx, abci.RequestQuery{
+		Path:   fmt.Sprintf("/store/%s/key", storeName),
+		Data:   key,
+		Height: info.Response.LastBlockHeight - 1,
+		Prove:  true,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return response.Value, response.Height, nil
+}
+
 func getHeightFromMetadata(md metadata.MD) (int64, error) {
 	height := md.Get(grpctypes.GRPCBlockHeightHeader)
 	if len(height) == 1 {
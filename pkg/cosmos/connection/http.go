@@ -4,14 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
+	"net"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
-	sdk "github.com/cosmos/cosmos-sdk/client"
 	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/mempool"
 	tendermint "github.com/tendermint/tendermint/rpc/client"
-	"github.com/tendermint/tendermint/rpc/client/http"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	"github.com/tendermint/tendermint/types"
 )
@@ -20,188 +21,296 @@ var ErrNoAvailableRPC = errors.New("no available rpc")
 
 type GetRpcHandler func(ctx context.Context, chainID string) ([]string, error)
 
+// maxRequestAttempts is a count of endpoints tried for a single request.
+const maxRequestAttempts = 3
+
 type HttpClient struct {
-	chainID     string
-	isInit      bool
-	endpoint    string
-	mutex       sync.RWMutex
-	queryClient tendermint.ABCIClient
-	getRpc      GetRpcHandler
+	chainID  string
+	mutex    sync.Mutex
+	getRpc   GetRpcHandler
+	scorer   *endpointScorer
+	breakers map[string]*circuitBreaker
 }
 
 func NewHttpClient(chainID string, getRpcHandler GetRpcHandler) *HttpClient {
 	return &HttpClient{
-		chainID: chainID,
-		getRpc:  getRpcHandler,
+		chainID:  chainID,
+		getRpc:   getRpcHandler,
+		scorer:   newEndpointScorer(),
+		breakers: make(map[string]*circuitBreaker),
 	}
 }
 
-func checkRpcClient(ctx context.Context, endpoint string, output chan<- *http.HTTP, wg *sync.WaitGroup) {
-	defer wg.Done()
-	rpcClient, err := sdk.NewClientFromNode(endpoint)
+// Refresh re-probes all chain endpoints to re-rank them.
+func (c *HttpClient) Refresh(ctx context.Context) error {
+	rpcEndpoints, err := c.getRpc(ctx, c.chainID)
 	if err != nil {
-		return
+		return err
 	}
 
-	result, err := rpcClient.Status(ctx)
-	if err != nil {
-		return
-	}
+	c.scorer.probe(ctx, rpcEndpoints)
+	_, _, err = c.scorer.best()
+	return err
+}
 
-	if result.SyncInfo.CatchingUp {
-		return
+// Health returns last known status of every chain endpoint.
+func (c *HttpClient) Health() []EndpointStatus {
+	statuses := c.scorer.statuses()
+	for index := range statuses {
+		statuses[index].CircuitOpen = c.getBreaker(statuses[index].Endpoint).isOpen()
 	}
 
-	output <- rpcClient
+	return statuses
 }
 
-func getHealthEndpoint(ctx context.Context, endpoints []string) (*http.HTTP, error) {
-	wg := &sync.WaitGroup{}
-	output := make(chan *http.HTTP)
-	final := make(chan []*http.HTTP)
+func (c *HttpClient) getBreaker(endpoint string) *circuitBreaker {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	breaker, ok := c.breakers[endpoint]
+	if !ok {
+		breaker = &circuitBreaker{}
+		c.breakers[endpoint] = breaker
+	}
+
+	return breaker
+}
 
-	go func() {
-		var result []*http.HTTP
-		for rpcClient := range output {
-			result = append(result, rpcClient)
+// getEndpoints returns healthy endpoints ordered from the best to the worst,
+// probing them only when previous probe results are stale.
+func (c *HttpClient) getEndpoints(ctx context.Context) ([]*endpointStats, error) {
+	if c.scorer.isStale() {
+		rpcEndpoints, err := c.getRpc(ctx, c.chainID)
+		if err != nil {
+			return nil, err
 		}
-		final <- result
-	}()
 
-	for _, endpoint := range endpoints {
-		wg.Add(1)
-		go checkRpcClient(ctx, endpoint, output, wg)
+		c.scorer.probe(ctx, rpcEndpoints)
 	}
 
-	wg.Wait()
-	close(output)
-	result := <-final
-	close(final)
-
-	if len(result) == 0 {
+	endpoints := c.scorer.ranked()
+	if len(endpoints) == 0 {
 		return nil, ErrNoAvailableRPC
 	}
 
-	var rpcClient *http.HTTP
-	n := len(result) - 1
-	switch n {
-	case 0:
-		rpcClient = result[0]
-	default:
-		rpcClient = result[rand.New(rand.NewSource(time.Now().UnixNano())).Intn(n)]
-	}
+	return endpoints, nil
+}
+
+type rpcCall func(ctx context.Context, client tendermint.Client) error
 
-	return rpcClient, nil
+// finalError is returned by request, which must not be retried on the next endpoints.
+type finalError struct {
+	err error
 }
 
-func (c *HttpClient) init(ctx context.Context) (tendermint.ABCIClient, string, error) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
+func (e *finalError) Error() string {
+	return e.err.Error()
+}
 
-	rpcEndpoints, err := c.getRpc(ctx, c.chainID)
+func (e *finalError) Unwrap() error {
+	return e.err
+}
+
+// call runs request on the best endpoint and, if retry is allowed, retries it on the next
+// healthy endpoints while caller's context is alive. Endpoints with open circuit are skipped.
+// Only cancellation by caller is not counted as endpoint failure, expired deadline is.
+func (c *HttpClient) call(ctx context.Context, method string, retry bool, request rpcCall) error {
+	endpoints, err := c.getEndpoints(ctx)
 	if err != nil {
-		return nil, "", err
+		return err
 	}
 
-	c.isInit = false
-	rpcClient, err := getHealthEndpoint(ctx, rpcEndpoints)
-	if err != nil {
-		return nil, "", err
+	maxAttempts := maxRequestAttempts
+	if !retry {
+		maxAttempts = 1
 	}
 
-	c.endpoint = rpcClient.Remote()
-	c.queryClient = rpcClient
-	c.isInit = true
-	return c.queryClient, c.endpoint, nil
-}
+	attempts := 0
+	var lastErr error
+	for _, stats := range endpoints {
+		if attempts == maxAttempts || ctx.Err() != nil {
+			break
+		}
 
-func (c *HttpClient) invalidate() {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.isInit = false
-}
+		breaker := c.getBreaker(stats.endpoint)
+		if !breaker.allow() {
+			continue
+		}
+
+		attempts++
+		start := time.Now()
+		err = request(ctx, stats.client)
+		// request cancelled by caller tells nothing about endpoint health
+		if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+			breaker.release()
+			return fmt.Errorf("error while %s request with endpoint %s; %w", method, stats.endpoint, err)
+		}
+
+		c.scorer.record(stats.endpoint, time.Since(start), err)
+		if err == nil {
+			breaker.success()
+			return nil
+		}
 
-func (c *HttpClient) getActiveClient(ctx context.Context) (tendermint.ABCIClient, string, error) {
-	c.mutex.RLock()
-	if !c.isInit {
-		c.mutex.RUnlock()
-		return c.init(ctx)
+		breaker.failure()
+		lastErr = fmt.Errorf("error while %s request with endpoint %s; %w", method, stats.endpoint, err)
+
+		var final *finalError
+		if errors.As(err, &final) {
+			break
+		}
 	}
-	defer c.mutex.RUnlock()
-	return c.queryClient, c.endpoint, nil
+
+	if lastErr == nil {
+		return ErrNoAvailableRPC
+	}
+
+	return lastErr
 }
 
 func (c *HttpClient) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
-	queryClient, endpoint, err := c.getActiveClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-	result, err := queryClient.ABCIInfo(ctx)
-	if err != nil {
-		c.invalidate()
-		return nil, fmt.Errorf("error while ABCIInfo request with endpoint %s; %s", endpoint, err.Error())
-	}
-	return result, nil
+	var result *ctypes.ResultABCIInfo
+	err := c.call(ctx, "ABCIInfo", true, func(ctx context.Context, client tendermint.Client) (err error) {
+		result, err = client.ABCIInfo(ctx)
+		return err
+	})
+	return result, err
 }
+
 func (c *HttpClient) ABCIQuery(ctx context.Context, path string, data bytes.HexBytes) (*ctypes.ResultABCIQuery, error) {
-	queryClient, endpoint, err := c.getActiveClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-	result, err := queryClient.ABCIQuery(ctx, path, data)
-	if err != nil {
-		c.invalidate()
-		return nil, fmt.Errorf("error while ABCIQuery request with endpoint %s; %s", endpoint, err.Error())
-	}
-	return result, nil
+	var result *ctypes.ResultABCIQuery
+	err := c.call(ctx, "ABCIQuery", true, func(ctx context.Context, client tendermint.Client) (err error) {
+		result, err = client.ABCIQuery(ctx, path, data)
+		return err
+	})
+	return result, err
 }
+
 func (c *HttpClient) ABCIQueryWithOptions(ctx context.Context, path string, data bytes.HexBytes,
 	opts tendermint.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
-	queryClient, endpoint, err := c.getActiveClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-	result, err := queryClient.ABCIQueryWithOptions(ctx, path, data, opts)
-	if err != nil {
-		c.invalidate()
-		return nil, fmt.Errorf("error while ABCIQueryWithOptions request with endpoint %s; %s", endpoint, err.Error())
-	}
-	return result, nil
+	var result *ctypes.ResultABCIQuery
+	err := c.call(ctx, "ABCIQueryWithOptions", true, func(ctx context.Context, client tendermint.Client) (err error) {
+		result, err = client.ABCIQueryWithOptions(ctx, path, data, opts)
+		return err
+	})
+	return result, err
 }
 
+// BroadcastTxCommit is never retried: it waits for the block and a retry could
+// exceed caller's deadline without giving any new information.
 func (c *HttpClient) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-	queryClient, endpoint, err := c.getActiveClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-	result, err := queryClient.BroadcastTxCommit(ctx, tx)
-	if err != nil {
-		c.invalidate()
-		return nil, fmt.Errorf("error while BroadcastTxCommit request with endpoint %s; %s", endpoint, err.Error())
+	var result *ctypes.ResultBroadcastTxCommit
+	err := c.call(ctx, "BroadcastTxCommit", false, func(ctx context.Context, client tendermint.Client) (err error) {
+		result, err = client.BroadcastTxCommit(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
+// isTxInMempool reports whether broadcast is rejected because the same tx is already in mempool,
+// that means one of the previous attempts has been delivered.
+func isTxInMempool(err error) bool {
+	return strings.Contains(err.Error(), mempool.ErrTxInCache.Error())
+}
+
+// isNotSent reports whether request failed before it reached the node,
+// so it is proven that tx is not delivered.
+func isNotSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
 	}
-	return result, nil
+
+	return errors.Is(err, syscall.ECONNREFUSED)
 }
-func (c *HttpClient) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	queryClient, endpoint, err := c.getActiveClient(ctx)
-	if err != nil {
-		return nil, err
+
+// maxUnconfirmedTxs is a max count of mempool txs returned by node.
+const maxUnconfirmedTxs = 100
+
+// isTxKnown checks whether node has already committed tx or keeps it in mempool.
+func isTxKnown(ctx context.Context, client tendermint.Client, tx types.Tx) bool {
+	if _, err := client.Tx(ctx, tx.Hash(), false); err == nil {
+		return true
 	}
-	result, err := queryClient.BroadcastTxAsync(ctx, tx)
+
+	limit := maxUnconfirmedTxs
+	unconfirmed, err := client.UnconfirmedTxs(ctx, &limit)
 	if err != nil {
-		c.invalidate()
-		return nil, fmt.Errorf("error while BroadcastTxAsync request with endpoint %s; %s", endpoint, err.Error())
+		return false
 	}
-	return result, nil
-}
-func (c *HttpClient) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	queryClient, endpoint, err := c.getActiveClient(ctx)
-	if err != nil {
-		return nil, err
+
+	for _, item := range unconfirmed.Txs {
+		if string(item) == string(tx) {
+			return true
+		}
 	}
-	result, err := queryClient.BroadcastTxSync(ctx, tx)
-	if err != nil {
-		c.invalidate()
-		return nil, fmt.Errorf("error while BroadcastTxSync request with endpoint %s; %s", endpoint, err.Error())
+
+	return false
+}
+
+// broadcast retries tx on the next endpoints only if it's proven that the previous attempt
+// hasn't reached the node. If result of the attempt is unknown, e.g. due to timeout,
+// the node is asked for tx by hash and tx isn't resent, so the caller never gets an error
+// for the tx, which is actually delivered.
+func (c *HttpClient) broadcast(ctx context.Context, method string, tx types.Tx,
+	request func(ctx context.Context, client tendermint.Client) (*ctypes.ResultBroadcastTx, error)) (*ctypes.ResultBroadcastTx, error) {
+	var result *ctypes.ResultBroadcastTx
+	delivered := &ctypes.ResultBroadcastTx{
+		Hash: tx.Hash(),
 	}
-	return result, nil
+
+	err := c.call(ctx, method, true, func(ctx context.Context, client tendermint.Client) error {
+		response, err := request(ctx, client)
+		if err == nil {
+			result = response
+			return nil
+		}
+
+		if isTxInMempool(err) {
+			result = delivered
+			return nil
+		}
+
+		if isNotSent(err) {
+			return err
+		}
+
+		if isTxKnown(ctx, client, tx) {
+			result = delivered
+			return nil
+		}
+
+		return &finalError{err: err}
+	})
+	return result, err
+}
+
+func (c *HttpClient) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.broadcast(ctx, "BroadcastTxAsync", tx, func(ctx context.Context, client tendermint.Client) (*ctypes.ResultBroadcastTx, error) {
+		return client.BroadcastTxAsync(ctx, tx)
+	})
+}
+
+func (c *HttpClient) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.broadcast(ctx, "BroadcastTxSync", tx, func(ctx context.Context, client tendermint.Client) (*ctypes.ResultBroadcastTx, error) {
+		return client.BroadcastTxSync(ctx, tx)
+	})
+}
+
+func (c *HttpClient) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
+	var result *ctypes.ResultUnconfirmedTxs
+	err := c.call(ctx, "UnconfirmedTxs", true, func(ctx context.Context, client tendermint.Client) (err error) {
+		result, err = client.UnconfirmedTxs(ctx, limit)
+		return err
+	})
+	return result, err
+}
+
+func (c *HttpClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	var result *ctypes.ResultBlock
+	err := c.call(ctx, "Block", true, func(ctx context.Context, client tendermint.Client) (err error) {
+		result, err = client.Block(ctx, height)
+		return err
+	})
+	return result, err
 }
@@ -0,0 +1,191 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	lighthttp "github.com/tendermint/tendermint/light/provider/http"
+	"github.com/tendermint/tendermint/light/store"
+	dbs "github.com/tendermint/tendermint/light/store/db"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	// lightTrustingPeriod must be shorter than unbonding period of the chain,
+	// one week is less than unbonding period of any cosmos chain.
+	lightTrustingPeriod = time.Hour * 24 * 7
+	// maxLightWitnesses is a count of endpoints cross-checking headers of the primary.
+	maxLightWitnesses = 3
+)
+
+var (
+	ErrVerificationDisabled = errors.New("query proof verification is disabled for the chain")
+	ErrMissingProof         = errors.New("query response has no proof")
+
+	ErrNoLightWitness = errors.New("light client requires witness distinct from the primary endpoint")
+
+	errEmptyTrustedStore = errors.New("trusted store is empty")
+)
+
+// TrustedHeader is a header of the chain trusted by operator, light client verifies later headers starting from it.
+type TrustedHeader struct {
+	Height int64
+	Hash   []byte
+}
+
+// LightClient verifies abci query proofs against app hash of the header verified
+// by tendermint light client. The first trusted header is configured by operator,
+// headers of the primary endpoint are cross-checked with witnesses, later headers are kept in the trusted store.
+type LightClient struct {
+	chainID string
+	trusted TrustedHeader
+	getRpc  GetRpcHandler
+	db      dbm.DB
+	mutex   sync.Mutex
+	client  *light.Client
+}
+
+func NewLightClient(chainID string, trusted TrustedHeader, getRpcHandler GetRpcHandler, db dbm.DB) *LightClient {
+	return &LightClient{
+		chainID: chainID,
+		trusted: trusted,
+		getRpc:  getRpcHandler,
+		db:      db,
+	}
+}
+
+func (c *LightClient) getClient(ctx context.Context) (*light.Client, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.client != nil {
+		return c.client, nil
+	}
+
+	endpoints, err := c.getRpc(ctx, c.chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(endpoints) == 0 {
+		return nil, ErrNoAvailableRPC
+	}
+
+	primary, err := lighthttp.New(c.chainID, endpoints[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// primary witnessing itself doesn't detect forged headers
+	var witnessEndpoints []string
+	for _, endpoint := range endpoints[1:] {
+		if endpoint != endpoints[0] && len(witnessEndpoints) < maxLightWitnesses {
+			witnessEndpoints = append(witnessEndpoints, endpoint)
+		}
+	}
+	if len(witnessEndpoints) == 0 {
+		return nil, ErrNoLightWitness
+	}
+
+	witnesses := make([]provider.Provider, 0, len(witnessEndpoints))
+	for _, endpoint := range witnessEndpoints {
+		witness, err := lighthttp.New(c.chainID, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		witnesses = append(witnesses, witness)
+	}
+
+	trustedStore := dbs.New(c.db, c.chainID)
+	client, err := c.restoreClient(primary, witnesses, trustedStore)
+	if err != nil {
+		client, err = c.bootstrapClient(ctx, primary, witnesses, trustedStore)
+	}
+	if err != nil {
+		err = fmt.Errorf("init light client; %s", err.Error())
+		return nil, err
+	}
+
+	c.client = client
+	return client, nil
+}
+
+// restoreClient creates light client from the trusted store, if it has not expired header.
+func (c *LightClient) restoreClient(primary provider.Provider, witnesses []provider.Provider, trustedStore store.Store) (*light.Client, error) {
+	height, err := trustedStore.LastLightBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	if height <= 0 {
+		return nil, errEmptyTrustedStore
+	}
+
+	lightBlock, err := trustedStore.LightBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	if lightBlock.Time.Add(lightTrustingPeriod).Before(time.Now()) {
+		return nil, light.ErrOldHeaderExpired{At: lightBlock.Time.Add(lightTrustingPeriod), Now: time.Now()}
+	}
+
+	return light.NewClientFromTrustedStore(c.chainID, lightTrustingPeriod, primary, witnesses, trustedStore)
+}
+
+// bootstrapClient starts from the header trusted by operator, header of the primary at the trusted height must have the same hash.
+func (c *LightClient) bootstrapClient(ctx context.Context, primary provider.Provider, witnesses []provider.Provider, trustedStore store.Store) (*light.Client, error) {
+	trustOptions := light.TrustOptions{
+		Period: lightTrustingPeriod,
+		Height: c.trusted.Height,
+		Hash:   c.trusted.Hash,
+	}
+
+	return light.NewClient(ctx, c.chainID, trustOptions, primary, witnesses, trustedStore)
+}
+
+// VerifyQuery checks proof of the store query response with requested key.
+// App hash of the state at query height is committed in the header of the next block.
+func (c *LightClient) VerifyQuery(ctx context.Context, path string, key []byte, response abci.ResponseQuery) error {
+	if response.ProofOps == nil || len(response.ProofOps.Ops) == 0 {
+		return ErrMissingProof
+	}
+
+	client, err := c.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	lightBlock, err := client.VerifyLightBlockAtHeight(ctx, response.Height+1, time.Now())
+	if err != nil {
+		err = fmt.Errorf("verify header at height %d; %s", response.Height+1, err.Error())
+		return err
+	}
+
+	storeName := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)[1]
+	keyPath := merkle.KeyPath{}.
+		AppendKey([]byte(storeName), merkle.KeyEncodingURL).
+		AppendKey(key, merkle.KeyEncodingURL)
+
+	proofRuntime := rootmulti.DefaultProofRuntime()
+	if response.Value == nil {
+		err = proofRuntime.VerifyAbsence(response.ProofOps, lightBlock.AppHash, keyPath.String())
+	} else {
+		err = proofRuntime.VerifyValue(response.ProofOps, lightBlock.AppHash, keyPath.String(), response.Value)
+	}
+	if err != nil {
+		err = fmt.Errorf("verify query proof; %s", err.Error())
+		return err
+	}
+
+	return nil
+}
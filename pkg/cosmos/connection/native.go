@@ -0,0 +1,144 @@
+package connection
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	googlerpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// NativeGrpcClient sends queries to grpc endpoints of the chain.
+type NativeGrpcClient struct {
+	pool              *endpointPool
+	interfaceRegistry types.InterfaceRegistry
+	codec             *codec.ProtoCodec
+	mutex             sync.Mutex
+	connections       map[string]*googlerpc.ClientConn
+}
+
+func NewNativeGrpcClient(chainID string, getGrpcHandler GetRpcHandler, interfaceRegistry types.InterfaceRegistry) *NativeGrpcClient {
+	client := &NativeGrpcClient{
+		pool:              newEndpointPool(chainID, "grpc", getGrpcHandler),
+		interfaceRegistry: interfaceRegistry,
+		codec:             codec.NewProtoCodec(interfaceRegistry),
+		connections:       make(map[string]*googlerpc.ClientConn),
+	}
+	client.pool.onEndpoints = client.closeRemoved
+
+	return client
+}
+
+// closeRemoved closes connections to endpoints, which are removed from registry.
+func (c *NativeGrpcClient) closeRemoved(endpoints []string) {
+	actual := make(map[string]struct{}, len(endpoints))
+	for _, endpoint := range endpoints {
+		actual[endpoint] = struct{}{}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for endpoint, connection := range c.connections {
+		if _, ok := actual[endpoint]; !ok {
+			_ = connection.Close()
+			delete(c.connections, endpoint)
+		}
+	}
+}
+
+// dialTarget converts registry grpc address to dial target, tls is used for 443 port or https scheme.
+func dialTarget(endpoint string) (string, bool) {
+	if !strings.Contains(endpoint, "://") {
+		_, port, _ := net.SplitHostPort(endpoint)
+		return endpoint, port == "443"
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint, false
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+
+	return net.JoinHostPort(u.Hostname(), port), u.Scheme == "https" || port == "443"
+}
+
+func (c *NativeGrpcClient) getConnection(endpoint string) (*googlerpc.ClientConn, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if connection, ok := c.connections[endpoint]; ok {
+		return connection, nil
+	}
+
+	target, secure := dialTarget(endpoint)
+	transportCredentials := insecure.NewCredentials()
+	if secure {
+		transportCredentials = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	connection, err := googlerpc.Dial(target,
+		googlerpc.WithTransportCredentials(transportCredentials),
+		googlerpc.WithDefaultCallOptions(googlerpc.ForceCodec(c.codec.GRPCCodec())))
+	if err != nil {
+		err = fmt.Errorf("dial grpc endpoint; %s", err.Error())
+		return nil, err
+	}
+
+	c.connections[endpoint] = connection
+	return connection, nil
+}
+
+func (c *NativeGrpcClient) hasEndpoints(ctx context.Context) bool {
+	return c.pool.hasEndpoints(ctx)
+}
+
+func (c *NativeGrpcClient) Invoke(ctx context.Context, method string, req, reply interface{}, opts ...googlerpc.CallOption) error {
+	err := c.pool.call(ctx, method, func(endpoint string) error {
+		connection, err := c.getConnection(endpoint)
+		if err != nil {
+			return err
+		}
+
+		return connection.Invoke(ctx, method, req, reply, opts...)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = types.UnpackInterfaces(reply, c.interfaceRegistry); err != nil {
+		err = fmt.Errorf("unpacking reply interfaces with codec; %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (c *NativeGrpcClient) NewStream(ctx context.Context, desc *googlerpc.StreamDesc, method string, opts ...googlerpc.CallOption) (googlerpc.ClientStream, error) {
+	var stream googlerpc.ClientStream
+	err := c.pool.call(ctx, method, func(endpoint string) error {
+		connection, err := c.getConnection(endpoint)
+		if err != nil {
+			return err
+		}
+
+		stream, err = connection.NewStream(ctx, desc, method, opts...)
+		return err
+	})
+	return stream, err
+}
@@ -0,0 +1,145 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrNoAvailableEndpoint = errors.New("no available endpoint")
+
+// endpointPool tries chain endpoints in registry order, skipping endpoints with open circuit.
+type endpointPool struct {
+	chainID      string
+	name         string
+	getEndpoints GetRpcHandler
+	mutex        sync.Mutex
+	breakers     map[string]*circuitBreaker
+	// onEndpoints is called with actual endpoints of the chain, so resources of removed endpoints are released.
+	onEndpoints func(endpoints []string)
+}
+
+func newEndpointPool(chainID string, name string, getEndpoints GetRpcHandler) *endpointPool {
+	return &endpointPool{
+		chainID:      chainID,
+		name:         name,
+		getEndpoints: getEndpoints,
+		breakers:     make(map[string]*circuitBreaker),
+	}
+}
+
+func (p *endpointPool) getBreaker(endpoint string) *circuitBreaker {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	breaker, ok := p.breakers[endpoint]
+	if !ok {
+		breaker = &circuitBreaker{}
+		p.breakers[endpoint] = breaker
+	}
+
+	return breaker
+}
+
+func (p *endpointPool) hasEndpoints(ctx context.Context) bool {
+	endpoints, err := p.getEndpoints(ctx, p.chainID)
+	return err == nil && len(endpoints) > 0
+}
+
+// call runs request on the next endpoints until it succeeds or fails with an error
+// returned by the chain itself, which would be the same on any other endpoint.
+func (p *endpointPool) call(ctx context.Context, method string, request func(endpoint string) error) error {
+	if p.getEndpoints == nil {
+		return ErrNoAvailableEndpoint
+	}
+
+	endpoints, err := p.getEndpoints(ctx, p.chainID)
+	if err != nil {
+		return err
+	}
+	p.evict(endpoints)
+
+	attempts := 0
+	var lastErr error
+	for _, endpoint := range endpoints {
+		if attempts == maxRequestAttempts || ctx.Err() != nil {
+			break
+		}
+
+		breaker := p.getBreaker(endpoint)
+		if !breaker.allow() {
+			continue
+		}
+
+		attempts++
+		err = request(endpoint)
+		// request interrupted by caller tells nothing about endpoint health
+		if err != nil && ctx.Err() != nil {
+			breaker.release()
+			return fmt.Errorf("error while %s %s request with endpoint %s; %w", method, p.name, endpoint, err)
+		}
+
+		if err == nil || !isTransportError(err) {
+			breaker.success()
+			return err
+		}
+
+		breaker.failure()
+		lastErr = fmt.Errorf("error while %s %s request with endpoint %s; %w", method, p.name, endpoint, err)
+	}
+
+	if lastErr == nil {
+		return ErrNoAvailableEndpoint
+	}
+
+	return lastErr
+}
+
+// evict forgets breakers of endpoints, which are removed from registry, and passes actual endpoints to onEndpoints.
+func (p *endpointPool) evict(endpoints []string) {
+	actual := make(map[string]struct{}, len(endpoints))
+	for _, endpoint := range endpoints {
+		actual[endpoint] = struct{}{}
+	}
+
+	p.mutex.Lock()
+	for endpoint := range p.breakers {
+		if _, ok := actual[endpoint]; !ok {
+			delete(p.breakers, endpoint)
+		}
+	}
+	onEndpoints := p.onEndpoints
+	p.mutex.Unlock()
+
+	if onEndpoints != nil {
+		onEndpoints(endpoints)
+	}
+}
+
+// isTransportError reports whether request failed because of endpoint or network,
+// so it makes sense to repeat it with another endpoint or transport.
+// Errors returned by the chain application are the same on any endpoint.
+func isTransportError(err error) bool {
+	if errors.Is(err, ErrNoAvailableRPC) || errors.Is(err, ErrNoAvailableEndpoint) {
+		return true
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		switch grpcErr.GRPCStatus().Code() {
+		case codes.Unavailable, codes.Unimplemented, codes.DeadlineExceeded, codes.ResourceExhausted:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
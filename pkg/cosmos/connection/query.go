@@ -0,0 +1,108 @@
+package connection
+
+import (
+	"context"
+	"sync/atomic"
+
+	googlerpc "google.golang.org/grpc"
+)
+
+// transport is a way to send grpc queries to the chain.
+type transport interface {
+	googlerpc.ClientConnInterface
+	// hasEndpoints reports whether chain registry has endpoints of the transport.
+	hasEndpoints(ctx context.Context) bool
+}
+
+type abciTransport struct {
+	*GrpcClient
+	httpClient *HttpClient
+}
+
+func (t abciTransport) hasEndpoints(ctx context.Context) bool {
+	endpoints, err := t.httpClient.getRpc(ctx, t.httpClient.chainID)
+	return err == nil && len(endpoints) > 0
+}
+
+// QueryClient sends grpc queries with the first transport, for which the chain has endpoints
+// in registry, and falls back to the next transports when endpoints of the current one fail.
+// Transports are tried in order: native grpc, abci over tendermint rpc, rest, or abci first,
+// if rpc is preferred.
+type QueryClient struct {
+	transports []transport
+	// preferred is an index of the last transport, which has served the request.
+	preferred int32
+}
+
+func NewQueryClient(grpcClient *NativeGrpcClient, httpClient *HttpClient, abciClient *GrpcClient, restClient *RestClient, preferRPC bool) *QueryClient {
+	abci := abciTransport{GrpcClient: abciClient, httpClient: httpClient}
+	transports := []transport{grpcClient, abci, restClient}
+	if preferRPC {
+		transports = []transport{abci, grpcClient, restClient}
+	}
+
+	return &QueryClient{
+		transports: transports,
+	}
+}
+
+// ordered returns transports with the preferred one first.
+func (c *QueryClient) ordered() []transport {
+	preferred := int(atomic.LoadInt32(&c.preferred))
+	result := make([]transport, 0, len(c.transports))
+	result = append(result, c.transports[preferred])
+	for index, t := range c.transports {
+		if index != preferred {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
+
+func (c *QueryClient) setPreferred(t transport) {
+	for index := range c.transports {
+		if c.transports[index] == t {
+			atomic.StoreInt32(&c.preferred, int32(index))
+			return
+		}
+	}
+}
+
+func (c *QueryClient) Invoke(ctx context.Context, method string, req, reply interface{}, opts ...googlerpc.CallOption) error {
+	err := error(ErrNoAvailableEndpoint)
+	for _, t := range c.ordered() {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if !t.hasEndpoints(ctx) {
+			continue
+		}
+
+		err = t.Invoke(ctx, method, req, reply, opts...)
+		if err == nil || !isTransportError(err) {
+			c.setPreferred(t)
+			return err
+		}
+	}
+
+	return err
+}
+
+func (c *QueryClient) NewStream(ctx context.Context, desc *googlerpc.StreamDesc, method string, opts ...googlerpc.CallOption) (googlerpc.ClientStream, error) {
+	err := error(ErrNoAvailableEndpoint)
+	for _, t := range c.ordered() {
+		if !t.hasEndpoints(ctx) {
+			continue
+		}
+
+		var stream googlerpc.ClientStream
+		stream, err = t.NewStream(ctx, desc, method, opts...)
+		if err == nil {
+			return stream, nil
+		}
+	}
+
+	return nil, err
+}
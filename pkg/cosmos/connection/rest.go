@@ -0,0 +1,373 @@
+package connection
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/gogo/protobuf/jsonpb"
+	gogoproto "github.com/gogo/protobuf/proto"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	googlerpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const restRequestTimeout = time.Second * 30
+
+var (
+	pathParamRegexp = regexp.MustCompile(`{([^}=]+)(=[^}]*)?}`)
+
+	ErrRestRouteNotFound = errors.New("rest route not found")
+)
+
+// restRoute is a rest gateway binding of grpc method taken from google.api.http option.
+type restRoute struct {
+	method  string
+	pattern string
+	body    string
+}
+
+// RestClient sends grpc queries to rest (lcd) endpoints of the chain, mapping methods
+// to urls with google.api.http options from proto descriptors.
+type RestClient struct {
+	pool              *endpointPool
+	interfaceRegistry types.InterfaceRegistry
+	codec             codec.Codec
+	httpClient        *http.Client
+	mutex             sync.RWMutex
+	routes            map[string]restRoute
+}
+
+func NewRestClient(chainID string, getRestHandler GetRpcHandler, interfaceRegistry types.InterfaceRegistry, codec codec.Codec) *RestClient {
+	return &RestClient{
+		pool:              newEndpointPool(chainID, "rest", getRestHandler),
+		interfaceRegistry: interfaceRegistry,
+		codec:             codec,
+		httpClient:        &http.Client{Timeout: restRequestTimeout},
+		routes:            make(map[string]restRoute),
+	}
+}
+
+func (c *RestClient) hasEndpoints(ctx context.Context) bool {
+	return c.pool.hasEndpoints(ctx)
+}
+
+func (c *RestClient) NewStream(context.Context, *googlerpc.StreamDesc, string, ...googlerpc.CallOption) (googlerpc.ClientStream, error) {
+	return nil, ErrStreamingNotSupported
+}
+
+func (c *RestClient) getRoute(method string, req interface{}) (restRoute, error) {
+	c.mutex.RLock()
+	route, ok := c.routes[method]
+	c.mutex.RUnlock()
+	if ok {
+		return route, nil
+	}
+
+	route, err := findRoute(method, req)
+	if err != nil {
+		return restRoute{}, err
+	}
+
+	c.mutex.Lock()
+	c.routes[method] = route
+	c.mutex.Unlock()
+	return route, nil
+}
+
+// findRoute looks up the method in file descriptor of the request message, services of
+// cosmos modules are declared in the same file with their requests.
+func findRoute(method string, req interface{}) (restRoute, error) {
+	message, ok := req.(interface{ Descriptor() ([]byte, []int) })
+	if !ok {
+		return restRoute{}, fmt.Errorf("%w: %s", ErrRestRouteNotFound, method)
+	}
+
+	compressed, _ := message.Descriptor()
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return restRoute{}, err
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return restRoute{}, err
+	}
+
+	file := &descriptorpb.FileDescriptorProto{}
+	if err = proto.Unmarshal(data, file); err != nil {
+		return restRoute{}, err
+	}
+
+	for _, service := range file.GetService() {
+		for _, serviceMethod := range service.GetMethod() {
+			if fmt.Sprintf("/%s.%s/%s", file.GetPackage(), service.GetName(), serviceMethod.GetName()) != method {
+				continue
+			}
+
+			rule, ok := proto.GetExtension(serviceMethod.GetOptions(), annotations.E_Http).(*annotations.HttpRule)
+			if !ok || rule == nil {
+				break
+			}
+
+			switch pattern := rule.GetPattern().(type) {
+			case *annotations.HttpRule_Get:
+				return restRoute{method: http.MethodGet, pattern: pattern.Get}, nil
+			case *annotations.HttpRule_Post:
+				return restRoute{method: http.MethodPost, pattern: pattern.Post, body: rule.GetBody()}, nil
+			}
+		}
+	}
+
+	return restRoute{}, fmt.Errorf("%w: %s", ErrRestRouteNotFound, method)
+}
+
+func isZeroValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == "" || v == "0"
+	case json.Number:
+		return v == "0"
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// addQueryParams flattens json fields into query parameters, nested fields are joined with dot.
+func addQueryParams(query url.Values, prefix string, fields map[string]interface{}) {
+	for name, value := range fields {
+		if isZeroValue(value) {
+			continue
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			addQueryParams(query, prefix+name+".", v)
+		case []interface{}:
+			for _, item := range v {
+				query.Add(prefix+name, fmt.Sprint(item))
+			}
+		default:
+			query.Set(prefix+name, fmt.Sprint(v))
+		}
+	}
+}
+
+// takeField removes field by dotted path from json object and returns its value.
+func takeField(fields map[string]interface{}, path string) interface{} {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		nested, ok := fields[name].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields = nested
+	}
+
+	name := names[len(names)-1]
+	value := fields[name]
+	delete(fields, name)
+	return value
+}
+
+func (c *RestClient) buildRequest(ctx context.Context, endpoint string, route restRoute, req interface{}) (*http.Request, error) {
+	message, ok := req.(gogoproto.Message)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type %T", req)
+	}
+
+	data, err := c.codec.MarshalJSON(message)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+
+	var pathErr error
+	path := pathParamRegexp.ReplaceAllStringFunc(route.pattern, func(param string) string {
+		match := pathParamRegexp.FindStringSubmatch(param)
+		value := takeField(fields, match[1])
+		if isZeroValue(value) {
+			pathErr = fmt.Errorf("empty path parameter %s", match[1])
+			return ""
+		}
+
+		if match[2] == "=**" {
+			return fmt.Sprint(value)
+		}
+		return url.PathEscape(fmt.Sprint(value))
+	})
+	if pathErr != nil {
+		return nil, status.Error(codes.InvalidArgument, pathErr.Error())
+	}
+
+	var body io.Reader
+	query := url.Values{}
+	switch route.body {
+	case "":
+		addQueryParams(query, "", fields)
+	case "*":
+		bodyData, marshalErr := json.Marshal(fields)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		body = bytes.NewReader(bodyData)
+	default:
+		bodyData, marshalErr := json.Marshal(takeField(fields, route.body))
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		body = bytes.NewReader(bodyData)
+		addQueryParams(query, "", fields)
+	}
+
+	requestURL := strings.TrimRight(endpoint, "/") + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, route.method, requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if heights := md.Get(grpctypes.GRPCBlockHeightHeader); len(heights) > 0 {
+			request.Header.Set(grpctypes.GRPCBlockHeightHeader, heights[0])
+		}
+	}
+
+	return request, nil
+}
+
+type restError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// responseError converts rest gateway error response to grpc status error.
+func responseError(statusCode int, data []byte) error {
+	response := restError{}
+	if err := json.Unmarshal(data, &response); err == nil && response.Code != 0 {
+		return status.Error(codes.Code(response.Code), response.Message)
+	}
+
+	switch statusCode {
+	case http.StatusNotFound, http.StatusNotImplemented, http.StatusMethodNotAllowed:
+		return status.Error(codes.Unimplemented, http.StatusText(statusCode))
+	case http.StatusTooManyRequests:
+		return status.Error(codes.ResourceExhausted, http.StatusText(statusCode))
+	default:
+		return status.Error(codes.Unavailable, http.StatusText(statusCode))
+	}
+}
+
+func (c *RestClient) Invoke(ctx context.Context, method string, req, reply interface{}, opts ...googlerpc.CallOption) error {
+	route, err := c.getRoute(method, req)
+	if err != nil {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+
+	message, ok := reply.(gogoproto.Message)
+	if !ok {
+		return fmt.Errorf("invalid reply type %T", reply)
+	}
+
+	var header http.Header
+	err = c.pool.call(ctx, method, func(endpoint string) error {
+		request, err := c.buildRequest(ctx, endpoint, route, req)
+		if err != nil {
+			if _, ok := status.FromError(err); !ok {
+				err = status.Error(codes.InvalidArgument, err.Error())
+			}
+			return err
+		}
+
+		response, err := c.httpClient.Do(request)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+
+		data, err := io.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+
+		if response.StatusCode != http.StatusOK {
+			return responseError(response.StatusCode, data)
+		}
+
+		// unknown fields are allowed, because endpoint may run newer version of the chain
+		unmarshaler := jsonpb.Unmarshaler{AnyResolver: c.interfaceRegistry, AllowUnknownFields: true}
+		if err = unmarshaler.Unmarshal(bytes.NewReader(data), message); err != nil {
+			err = fmt.Errorf("unmarshaling rest reply; %s", err.Error())
+			return status.Error(codes.Unavailable, err.Error())
+		}
+
+		header = response.Header
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = types.UnpackInterfaces(reply, c.interfaceRegistry); err != nil {
+		err = fmt.Errorf("unpacking reply interfaces with codec; %s", err.Error())
+		return err
+	}
+
+	md := metadata.MD{}
+	for _, key := range []string{"Grpc-Metadata-" + grpctypes.GRPCBlockHeightHeader, grpctypes.GRPCBlockHeightHeader} {
+		if height := header.Get(key); height != "" {
+			md.Set(grpctypes.GRPCBlockHeightHeader, height)
+			break
+		}
+	}
+
+	for _, callOpt := range opts {
+		headerOpt, ok := callOpt.(googlerpc.HeaderCallOption)
+		if !ok {
+			continue
+		}
+
+		*headerOpt.HeaderAddr = md
+	}
+
+	return nil
+}
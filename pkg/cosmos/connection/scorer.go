@@ -0,0 +1,261 @@
+package connection
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/client"
+	"github.com/tendermint/tendermint/rpc/client/http"
+)
+
+const (
+	// latencyWeight is a smoothing factor of exponentially weighted moving averages.
+	latencyWeight = 0.3
+	// maxHeightLag is a count of blocks endpoint may fall behind the highest one to be used.
+	maxHeightLag = 5
+	// heightLagPenalty is added to endpoint score for every block it falls behind.
+	heightLagPenalty = time.Millisecond * 200
+	// probeTTL is a period while probe results are used for endpoint selection without re-probing.
+	probeTTL = time.Minute * 5
+	// probeTimeout limits status request of a single endpoint, tendermint client has no request timeout,
+	// so hanging endpoint is marked unavailable instead of holding the whole probe.
+	probeTimeout = time.Second * 5
+)
+
+type endpointStats struct {
+	endpoint   string
+	client     *http.HTTP
+	latency    time.Duration
+	errorRate  float64
+	height     int64
+	catchingUp bool
+	available  bool
+	checkedAt  time.Time
+}
+
+// score returns comparable endpoint cost, the lower is the better.
+func (s *endpointStats) score(maxHeight int64) float64 {
+	lag := maxHeight - s.height
+	if lag < 0 {
+		lag = 0
+	}
+
+	cost := float64(s.latency) + float64(lag)*float64(heightLagPenalty)
+	return cost * (1 + 10*s.errorRate)
+}
+
+func (s *endpointStats) isHealthy(maxHeight int64) bool {
+	return s.available && !s.catchingUp && maxHeight-s.height <= maxHeightLag
+}
+
+// endpointScorer tracks latency, error rate and block height lag of chain rpc endpoints
+// and ranks them for selection.
+type endpointScorer struct {
+	mutex     sync.RWMutex
+	stats     map[string]*endpointStats
+	probedAt  time.Time
+	maxHeight int64
+}
+
+func newEndpointScorer() *endpointScorer {
+	return &endpointScorer{
+		mutex: sync.RWMutex{},
+		stats: make(map[string]*endpointStats),
+	}
+}
+
+func movingAverage(previous float64, value float64) float64 {
+	return previous*(1-latencyWeight) + value*latencyWeight
+}
+
+func probeEndpoint(ctx context.Context, endpoint string, client *http.HTTP) endpointStats {
+	stats := endpointStats{
+		endpoint:  endpoint,
+		client:    client,
+		checkedAt: time.Now(),
+	}
+
+	if client == nil {
+		rpcClient, err := sdk.NewClientFromNode(endpoint)
+		if err != nil {
+			return stats
+		}
+		stats.client = rpcClient
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	result, err := stats.client.Status(ctx)
+	stats.latency = time.Since(start)
+	if err != nil {
+		return stats
+	}
+
+	stats.available = true
+	stats.height = result.SyncInfo.LatestBlockHeight
+	stats.catchingUp = result.SyncInfo.CatchingUp
+	return stats
+}
+
+// probe status-checks all endpoints concurrently and updates their stats. Endpoints, which probes are
+// interrupted by caller, keep their previous stats.
+func (s *endpointScorer) probe(ctx context.Context, endpoints []string) {
+	s.mutex.RLock()
+	clients := make(map[string]*http.HTTP)
+	for _, endpoint := range endpoints {
+		if stats, ok := s.stats[endpoint]; ok {
+			clients[endpoint] = stats.client
+		}
+	}
+	s.mutex.RUnlock()
+
+	wg := &sync.WaitGroup{}
+	results := make([]endpointStats, len(endpoints))
+	for index, endpoint := range endpoints {
+		wg.Add(1)
+		go func(index int, endpoint string) {
+			defer wg.Done()
+			results[index] = probeEndpoint(ctx, endpoint, clients[endpoint])
+		}(index, endpoint)
+	}
+	wg.Wait()
+	interrupted := ctx.Err() != nil
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	updated := make(map[string]*endpointStats)
+	var maxHeight int64
+	for index := range results {
+		result := results[index]
+		previous, ok := s.stats[result.endpoint]
+		if ok && !result.available && interrupted {
+			kept := *previous
+			if kept.height > maxHeight {
+				maxHeight = kept.height
+			}
+			updated[kept.endpoint] = &kept
+			continue
+		}
+		if ok && previous.available && result.available {
+			result.latency = time.Duration(movingAverage(float64(previous.latency), float64(result.latency)))
+		}
+		if ok {
+			result.errorRate = previous.errorRate
+		}
+		if !result.available {
+			result.errorRate = movingAverage(result.errorRate, 1)
+		}
+		if result.height > maxHeight {
+			maxHeight = result.height
+		}
+		updated[result.endpoint] = &result
+	}
+
+	s.stats = updated
+	s.maxHeight = maxHeight
+	s.probedAt = time.Now()
+}
+
+// record updates endpoint stats with result of regular request.
+func (s *endpointScorer) record(endpoint string, latency time.Duration, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats, ok := s.stats[endpoint]
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		stats.errorRate = movingAverage(stats.errorRate, 1)
+		return
+	}
+
+	stats.errorRate = movingAverage(stats.errorRate, 0)
+	stats.latency = time.Duration(movingAverage(float64(stats.latency), float64(latency)))
+}
+
+func (s *endpointScorer) isStale() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.stats) == 0 || time.Since(s.probedAt) > probeTTL
+}
+
+// ranked returns healthy endpoints ordered from the best to the worst.
+func (s *endpointScorer) ranked() []*endpointStats {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var result []*endpointStats
+	for _, stats := range s.stats {
+		if stats.isHealthy(s.maxHeight) {
+			result = append(result, stats)
+		}
+	}
+
+	scores := make(map[string]float64, len(result))
+	for _, stats := range result {
+		scores[stats.endpoint] = stats.score(s.maxHeight)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return scores[result[i].endpoint] < scores[result[j].endpoint]
+	})
+	return result
+}
+
+// best returns client of the endpoint with the lowest score.
+func (s *endpointScorer) best() (*http.HTTP, string, error) {
+	ranked := s.ranked()
+	if len(ranked) == 0 {
+		return nil, "", ErrNoAvailableRPC
+	}
+
+	return ranked[0].client, ranked[0].endpoint, nil
+}
+
+// EndpointStatus is a snapshot of endpoint health.
+type EndpointStatus struct {
+	Endpoint    string
+	Available   bool
+	CatchingUp  bool
+	Height      int64
+	HeightLag   int64
+	Latency     time.Duration
+	ErrorRate   float64
+	CircuitOpen bool
+	CheckedAt   time.Time
+}
+
+func (s *endpointScorer) statuses() []EndpointStatus {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := make([]EndpointStatus, 0, len(s.stats))
+	for _, stats := range s.stats {
+		var lag int64
+		if stats.available {
+			lag = s.maxHeight - stats.height
+		}
+		result = append(result, EndpointStatus{
+			Endpoint:   stats.endpoint,
+			Available:  stats.isHealthy(s.maxHeight),
+			CatchingUp: stats.catchingUp,
+			Height:     stats.height,
+			HeightLag:  lag,
+			Latency:    stats.latency,
+			ErrorRate:  stats.errorRate,
+			CheckedAt:  stats.checkedAt,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Endpoint < result[j].Endpoint
+	})
+	return result
+}
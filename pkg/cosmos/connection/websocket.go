@@ -2,17 +2,52 @@ package connection
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
-	sdk "github.com/cosmos/cosmos-sdk/client"
+	"github.com/Mobile-Web3/backend/pkg/log"
 	"github.com/google/uuid"
-	"github.com/tendermint/tendermint/rpc/client/http"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
-	"github.com/tendermint/tendermint/types"
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+)
+
+const (
+	// maxSubscriptions is a cap of concurrent subscriptions per chain.
+	maxSubscriptions = 1000
+	// subscriptionBuffer is a count of events buffered for slow subscriber, next events are dropped.
+	subscriptionBuffer = 8
+	// subscribeTimeout limits waiting for websocket to accept subscribe request.
+	subscribeTimeout = time.Second * 10
+	// websocketCheckInterval is a period of checking that websocket is alive.
+	websocketCheckInterval = time.Second * 10
+	// maxReconnectAttempts is a count of reconnects to the same endpoint before switching to the next one.
+	maxReconnectAttempts = 5
+	// txQuery is the only query subscribed in tendermint, queries of subscribers are matched by the client.
+	txQuery = "tm.event = 'Tx'"
+)
+
+var (
+	ErrTooManySubscriptions = errors.New("too many subscriptions")
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrWebsocketStopped     = errors.New("websocket client is stopped")
+)
+
+type TxStatus string
+
+const (
+	TxStatusSuccess TxStatus = "success"
+	TxStatusFailure TxStatus = "failure"
+	TxStatusTimeout TxStatus = "timeout"
 )
 
 type TxEvent struct {
 	TxHash    string
+	Status    TxStatus
+	Height    int64
 	Code      uint32
 	Log       string
 	Info      string
@@ -20,107 +55,348 @@ type TxEvent struct {
 	GasWanted int64
 }
 
-type TxEventHandler func(ctx context.Context, event TxEvent, params map[string]interface{}) error
+// TransferEvent is an incoming transfer of coins to the address, IBC transfers have the channel they are received with.
+type TransferEvent struct {
+	TxHash    string
+	Height    int64
+	Recipient string
+	Senders   []string
+	Amount    string
+	Channel   string
+}
+
+// Subscription receives events of the query until it is cancelled or timed out.
+type Subscription struct {
+	ID     string
+	Query  string
+	query  *query.Query
+	events chan ctypes.ResultEvent
+	done   chan struct{}
+	timer  *time.Timer
+	client *WebsocketClient
+}
+
+func (s *Subscription) Events() <-chan ctypes.ResultEvent {
+	return s.events
+}
+
+// Done is closed when subscription is cancelled or timed out.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
 
+func (s *Subscription) Cancel() {
+	s.client.unsubscribe(s)
+}
+
+// WebsocketClient keeps one websocket connection per chain with the single subscription to all txs,
+// because tendermint limits subscriptions per client. Queries of subscribers are matched with events by the client.
+// Connection is reconnected by tendermint client, and if it gives up, the next endpoint is used.
 type WebsocketClient struct {
-	chainID       string
-	getRpc        GetRpcHandler
-	handleTxEvent TxEventHandler
-	cancelChannel chan string
+	chainID string
+	getRpc  GetRpcHandler
+	logger  log.Logger
+
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	// connMutex guards websocket connection, network requests are sent under it only,
+	// so events are dispatched while websocket is connecting.
+	connMutex  sync.Mutex
+	ws         *jsonrpcclient.WSClient
+	endpoint   string
+	subscribed bool
+	watching   bool
+
+	mutex         sync.RWMutex
+	subscriptions map[string]*Subscription
 }
 
-func NewWebsocketClient(chainID string, getRpcHandler GetRpcHandler, txEventHandler TxEventHandler) *WebsocketClient {
+func NewWebsocketClient(chainID string, getRpcHandler GetRpcHandler, logger log.Logger) *WebsocketClient {
 	return &WebsocketClient{
 		chainID:       chainID,
 		getRpc:        getRpcHandler,
-		handleTxEvent: txEventHandler,
-		cancelChannel: make(chan string),
+		logger:        logger,
+		stop:          make(chan struct{}),
+		subscriptions: make(map[string]*Subscription),
 	}
 }
 
-func (c *WebsocketClient) listenTxEvents(
-	subscriber string,
-	query string,
-	params map[string]interface{},
-	channel <-chan ctypes.ResultEvent,
-	client *http.HTTP) {
-	ctx := context.Background()
-	defer client.Unsubscribe(ctx, subscriber, query)
-	defer client.Stop()
+// Stop cancels all subscriptions, closes websocket and stops watching it.
+func (c *WebsocketClient) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+
+		c.mutex.RLock()
+		subscriptions := make([]*Subscription, 0, len(c.subscriptions))
+		for _, subscription := range c.subscriptions {
+			subscriptions = append(subscriptions, subscription)
+		}
+		c.mutex.RUnlock()
+
+		for _, subscription := range subscriptions {
+			subscription.Cancel()
+		}
+
+		c.connMutex.Lock()
+		defer c.connMutex.Unlock()
+		if c.ws != nil && c.ws.IsRunning() {
+			_ = c.ws.Stop()
+		}
+		c.ws = nil
+	})
+}
+
+func (c *WebsocketClient) countSubscriptions() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.subscriptions)
+}
+
+// connect opens websocket to the endpoint next to the current one and subscribes to txs.
+// Must be called with locked connMutex.
+func (c *WebsocketClient) connect(ctx context.Context) error {
+	if c.ws != nil && c.ws.IsRunning() {
+		if c.subscribed {
+			return nil
+		}
+
+		if err := c.subscribeTxs(); err != nil {
+			err = fmt.Errorf("subscribing for events to %s; %s", c.endpoint, err.Error())
+			return err
+		}
+		c.subscribed = true
+		return nil
+	}
+
+	endpoints, err := c.getRpc(ctx, c.chainID)
+	if err != nil {
+		return err
+	}
+
+	start := 0
+	for index, endpoint := range endpoints {
+		if endpoint == c.endpoint {
+			start = index + 1
+			break
+		}
+	}
+
+	err = ErrNoAvailableRPC
+	for index := range endpoints {
+		endpoint := endpoints[(start+index)%len(endpoints)]
+		var ws *jsonrpcclient.WSClient
+		ws, err = jsonrpcclient.NewWS(endpoint, "/websocket",
+			jsonrpcclient.MaxReconnectAttempts(maxReconnectAttempts),
+			jsonrpcclient.OnReconnect(c.resubscribe))
+		if err != nil {
+			continue
+		}
+
+		if err = ws.Start(); err != nil {
+			continue
+		}
+
+		c.ws = ws
+		c.endpoint = endpoint
+		go c.listen(ws)
+
+		if err = c.subscribeTxs(); err != nil {
+			_ = ws.Stop()
+			c.ws = nil
+			continue
+		}
+		c.subscribed = true
+
+		if !c.watching {
+			c.watching = true
+			go c.watch()
+		}
+		return nil
+	}
+
+	err = fmt.Errorf("tendermint websocket connecting; %s", err.Error())
+	return err
+}
+
+func (c *WebsocketClient) subscribeTxs() error {
+	ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+	defer cancel()
+	return c.ws.Subscribe(ctx, txQuery)
+}
+
+// resubscribe restores subscription after tendermint client has reconnected to the same endpoint.
+// It is called by the reconnecting tendermint client, so connMutex is not locked and requests don't block subscribers.
+func (c *WebsocketClient) resubscribe() {
+	if c.countSubscriptions() == 0 {
+		return
+	}
+
+	c.connMutex.Lock()
+	ws := c.ws
+	c.connMutex.Unlock()
+	if ws == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+	defer cancel()
+	_ = ws.Subscribe(ctx, txQuery)
+}
+
+// watch switches to the next endpoint when tendermint client gives up reconnecting.
+func (c *WebsocketClient) watch() {
+	ticker := time.NewTicker(websocketCheckInterval)
+	defer ticker.Stop()
+
 	for {
 		select {
-		case sub := <-c.cancelChannel:
-			if sub == subscriber {
-				return
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if c.countSubscriptions() == 0 {
+			continue
+		}
+
+		c.connMutex.Lock()
+		if c.ws == nil || !c.ws.IsRunning() {
+			ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+			if err := c.connect(ctx); err != nil {
+				c.logger.Error(fmt.Errorf("websocket %s reconnecting; %s", c.chainID, err.Error()))
 			}
-		case event, ok := <-channel:
+			cancel()
+		}
+		c.connMutex.Unlock()
+	}
+}
+
+func (c *WebsocketClient) listen(ws *jsonrpcclient.WSClient) {
+	for {
+		select {
+		case response, ok := <-ws.ResponsesCh:
 			if !ok {
 				return
 			}
 
-			txData := event.Data.(types.EventDataTx)
-			txHash := ""
-			if len(event.Events["tx.hash"]) > 0 {
-				txHash = event.Events["tx.hash"][0]
+			if response.Error != nil || len(response.Result) == 0 {
+				continue
 			}
 
-			txEvent := TxEvent{
-				TxHash:    txHash,
-				Code:      txData.Result.Code,
-				Log:       txData.Result.Log,
-				Info:      txData.Result.Info,
-				GasUsed:   txData.Result.GasUsed,
-				GasWanted: txData.Result.GasWanted,
+			event := ctypes.ResultEvent{}
+			if err := tmjson.Unmarshal(response.Result, &event); err != nil || event.Query == "" {
+				continue
 			}
 
-			_ = c.handleTxEvent(ctx, txEvent, params)
+			c.dispatch(event)
+		case <-ws.Quit():
 			return
 		}
 	}
 }
 
-func (c *WebsocketClient) subscribeToTx(
-	ctx context.Context,
-	address string,
-	endpoint string,
-	params map[string]interface{},
-	client *http.HTTP) (string, error) {
-	subscriber := fmt.Sprintf("%s-%s-%s", c.chainID, address, uuid.New().String())
-	query := fmt.Sprintf("tm.event = 'Tx' AND transfer.sender = '%s'", address)
-	eventsChannel, err := client.Subscribe(ctx, subscriber, query)
+// dispatch passes the event to subscribers, which queries match it.
+// Events are dropped for subscribers with full buffer, so slow subscriber doesn't block others.
+func (c *WebsocketClient) dispatch(event ctypes.ResultEvent) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	for _, subscription := range c.subscriptions {
+		if matches, err := subscription.query.Matches(event.Events); err != nil || !matches {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			c.logger.Info(fmt.Sprintf("websocket %s: event of %s dropped for slow subscription %s", c.chainID, event.Query, subscription.ID))
+		}
+	}
+}
+
+// Subscribe starts receiving tx events matching the query until subscription is cancelled or timeout is over.
+func (c *WebsocketClient) Subscribe(ctx context.Context, subscriptionQuery string, timeout time.Duration) (*Subscription, error) {
+	parsedQuery, err := query.New(subscriptionQuery)
 	if err != nil {
-		err = fmt.Errorf("subscribing for tx events to %s; %s", endpoint, err.Error())
-		return "", err
+		return nil, err
+	}
+
+	if c.countSubscriptions() >= maxSubscriptions {
+		return nil, ErrTooManySubscriptions
+	}
+
+	c.connMutex.Lock()
+	defer c.connMutex.Unlock()
+
+	select {
+	case <-c.stop:
+		return nil, ErrWebsocketStopped
+	default:
+	}
+
+	if err = c.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	subscription := &Subscription{
+		ID:     fmt.Sprintf("%s-%s", c.chainID, uuid.New().String()),
+		Query:  subscriptionQuery,
+		query:  parsedQuery,
+		events: make(chan ctypes.ResultEvent, subscriptionBuffer),
+		done:   make(chan struct{}),
+		client: c,
 	}
 
-	go c.listenTxEvents(subscriber, query, params, eventsChannel, client)
-	return subscriber, nil
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.subscriptions) >= maxSubscriptions {
+		return nil, ErrTooManySubscriptions
+	}
+
+	subscription.timer = time.AfterFunc(timeout, subscription.Cancel)
+	c.subscriptions[subscription.ID] = subscription
+	return subscription, nil
 }
 
-func (c *WebsocketClient) SubscribeToTx(ctx context.Context, address string, params map[string]interface{}) (string, error) {
-	endpoints, err := c.getRpc(ctx, c.chainID)
-	if err != nil {
-		return "", err
+func (c *WebsocketClient) unsubscribe(subscription *Subscription) {
+	c.mutex.Lock()
+	if _, ok := c.subscriptions[subscription.ID]; !ok {
+		c.mutex.Unlock()
+		return
 	}
 
-	var client *http.HTTP
-	for _, endpoint := range endpoints {
-		client, err = sdk.NewClientFromNode(endpoint)
-		if err != nil {
-			continue
-		}
+	subscription.timer.Stop()
+	delete(c.subscriptions, subscription.ID)
+	close(subscription.done)
+	c.mutex.Unlock()
 
-		if err = client.Start(); err != nil {
-			continue
-		}
+	// txs are not received without subscribers, subscription could be added while connMutex is awaited
+	c.connMutex.Lock()
+	defer c.connMutex.Unlock()
 
-		return c.subscribeToTx(ctx, address, endpoint, params, client)
+	if !c.subscribed || c.countSubscriptions() > 0 {
+		return
 	}
 
-	err = fmt.Errorf("tendermint websocket connecting; %s", err.Error())
-	return "", err
+	c.subscribed = false
+	if c.ws != nil && c.ws.IsActive() {
+		ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+		defer cancel()
+		_ = c.ws.Unsubscribe(ctx, txQuery)
+	}
 }
 
-func (c *WebsocketClient) UnsubscribeFromTx(subscriber string) {
-	c.cancelChannel <- subscriber
+// Unsubscribe cancels subscription by its id.
+func (c *WebsocketClient) Unsubscribe(id string) error {
+	c.mutex.RLock()
+	subscription, ok := c.subscriptions[id]
+	c.mutex.RUnlock()
+	if !ok {
+		return ErrSubscriptionNotFound
+	}
+
+	subscription.Cancel()
+	return nil
 }
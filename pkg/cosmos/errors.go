@@ -0,0 +1,23 @@
+package cosmos
+
+import (
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IsNotFound reports whether query has failed because requested item does not exist.
+// Some modules respond with invalid argument error in this case, e.g. gov vote and staking delegation queries,
+// and abci transport wraps status errors, so they are unwrapped.
+func IsNotFound(err error) bool {
+	var statusErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+
+	grpcStatus := statusErr.GRPCStatus()
+	return grpcStatus.Code() == codes.NotFound ||
+		(grpcStatus.Code() == codes.InvalidArgument && strings.Contains(grpcStatus.Message(), "not found"))
+}
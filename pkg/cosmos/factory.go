@@ -41,9 +41,25 @@ func (c *Client) getAccount(ctx context.Context, address string, chainID string)
 		return nil, err
 	}
 
+	if c.IsVerificationEnabled(chainID) {
+		if err = c.verifyAccount(ctx, chainID, address, acc); err != nil {
+			return nil, err
+		}
+	}
+
 	return acc, nil
 }
 
+// GetAccountSequence returns committed sequence of the account.
+func (c *Client) GetAccountSequence(ctx context.Context, chainID string, address string) (uint64, error) {
+	account, err := c.getAccount(ctx, address, chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	return account.GetSequence(), nil
+}
+
 func (c *Client) prepareTxFactory(ctx context.Context, chainID string, chainPrefix string, factory tx.Factory, address types.Address) (tx.Factory, error) {
 	accNumber := factory.AccountNumber()
 	accSequence := factory.Sequence()
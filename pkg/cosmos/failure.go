@@ -0,0 +1,50 @@
+package cosmos
+
+import "strings"
+
+// FailureReason is a cause of failed tx parsed from its log.
+type FailureReason string
+
+const (
+	FailureInsufficientFunds FailureReason = "insufficient_funds"
+	FailureInsufficientFee   FailureReason = "insufficient_fee"
+	FailureOutOfGas          FailureReason = "out_of_gas"
+	FailureSequenceMismatch  FailureReason = "sequence_mismatch"
+	FailureUnauthorized      FailureReason = "unauthorized"
+	FailureInvalidAddress    FailureReason = "invalid_address"
+	FailureInvalidCoins      FailureReason = "invalid_coins"
+	FailureMemoTooLarge      FailureReason = "memo_too_large"
+	FailureTimeout           FailureReason = "tx_timeout"
+	FailureUnknown           FailureReason = "unknown"
+)
+
+// failureMarkers are descriptions of sdk errors, which are the last part of ABCI log of failed tx,
+// e.g. "insufficient funds: 10uatom is smaller than 20uatom: insufficient funds".
+var failureMarkers = []struct {
+	marker string
+	reason FailureReason
+}{
+	{"out of gas", FailureOutOfGas},
+	{"insufficient fee", FailureInsufficientFee},
+	{"insufficient funds", FailureInsufficientFunds},
+	{"account sequence mismatch", FailureSequenceMismatch},
+	{"incorrect account sequence", FailureSequenceMismatch},
+	{"signature verification failed", FailureUnauthorized},
+	{"unauthorized", FailureUnauthorized},
+	{"invalid address", FailureInvalidAddress},
+	{"invalid coins", FailureInvalidCoins},
+	{"memo too large", FailureMemoTooLarge},
+	{"tx timeout height", FailureTimeout},
+}
+
+// ParseFailureReason finds the reason of failed tx in its ABCI log.
+func ParseFailureReason(log string) FailureReason {
+	log = strings.ToLower(log)
+	for _, failure := range failureMarkers {
+		if strings.Contains(log, failure.marker) {
+			return failure.reason
+		}
+	}
+
+	return FailureUnknown
+}
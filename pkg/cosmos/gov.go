@@ -0,0 +1,157 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+)
+
+// proposalsPageLimit is a page size of proposals query.
+const proposalsPageLimit = 100
+
+// Proposal is a governance proposal in voting period, title is empty if its content is unknown.
+type Proposal struct {
+	ID              uint64
+	Title           string
+	VotingStartTime time.Time
+	VotingEndTime   time.Time
+}
+
+// GetVotingProposals returns proposals in voting period. Legacy gov query is used first, because it is
+// supported by all chains, and gov v1 query is used when legacy one fails on proposals with new messages.
+func (c *Client) GetVotingProposals(ctx context.Context, chainID string) ([]Proposal, error) {
+	result, err := c.getLegacyVotingProposals(ctx, chainID)
+	if err == nil {
+		return result, nil
+	}
+
+	result, v1Err := c.getVotingProposals(ctx, chainID)
+	if v1Err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) getLegacyVotingProposals(ctx context.Context, chainID string) ([]Proposal, error) {
+	client := govv1beta1.NewQueryClient(c.GetChainGrpcClient(chainID))
+
+	var result []Proposal
+	var nextKey []byte
+	for {
+		response, err := client.Proposals(ctx, &govv1beta1.QueryProposalsRequest{
+			ProposalStatus: govv1beta1.StatusVotingPeriod,
+			Pagination: &query.PageRequest{
+				Key:   nextKey,
+				Limit: proposalsPageLimit,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, proposal := range response.Proposals {
+			title := ""
+			if err = proposal.UnpackInterfaces(c.interfaceRegistry); err == nil && proposal.GetContent() != nil {
+				title = proposal.GetContent().GetTitle()
+			}
+
+			result = append(result, Proposal{
+				ID:              proposal.ProposalId,
+				Title:           title,
+				VotingStartTime: proposal.VotingStartTime,
+				VotingEndTime:   proposal.VotingEndTime,
+			})
+		}
+
+		if response.Pagination == nil || len(response.Pagination.NextKey) == 0 {
+			return result, nil
+		}
+		nextKey = response.Pagination.NextKey
+	}
+}
+
+func (c *Client) getVotingProposals(ctx context.Context, chainID string) ([]Proposal, error) {
+	client := govv1.NewQueryClient(c.GetChainGrpcClient(chainID))
+
+	var result []Proposal
+	var nextKey []byte
+	for {
+		response, err := client.Proposals(ctx, &govv1.QueryProposalsRequest{
+			ProposalStatus: govv1.StatusVotingPeriod,
+			Pagination: &query.PageRequest{
+				Key:   nextKey,
+				Limit: proposalsPageLimit,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, proposal := range response.Proposals {
+			item := Proposal{
+				ID:    proposal.Id,
+				Title: c.proposalTitle(proposal),
+			}
+			if proposal.VotingStartTime != nil {
+				item.VotingStartTime = *proposal.VotingStartTime
+			}
+			if proposal.VotingEndTime != nil {
+				item.VotingEndTime = *proposal.VotingEndTime
+			}
+			result = append(result, item)
+		}
+
+		if response.Pagination == nil || len(response.Pagination.NextKey) == 0 {
+			return result, nil
+		}
+		nextKey = response.Pagination.NextKey
+	}
+}
+
+// proposalTitle finds title in legacy content message or in json metadata of the proposal.
+func (c *Client) proposalTitle(proposal *govv1.Proposal) string {
+	if err := proposal.UnpackInterfaces(c.interfaceRegistry); err == nil {
+		for _, message := range proposal.Messages {
+			legacy, ok := message.GetCachedValue().(*govv1.MsgExecLegacyContent)
+			if !ok {
+				continue
+			}
+
+			if content, contentErr := govv1.LegacyContentFromMessage(legacy); contentErr == nil {
+				return content.GetTitle()
+			}
+		}
+	}
+
+	metadata := struct {
+		Title string `json:"title"`
+	}{}
+	if err := json.Unmarshal([]byte(proposal.Metadata), &metadata); err == nil {
+		return metadata.Title
+	}
+
+	return ""
+}
+
+// HasVoted checks whether the voter has voted for the proposal.
+func (c *Client) HasVoted(ctx context.Context, chainID string, proposalID uint64, voter string) (bool, error) {
+	client := govv1beta1.NewQueryClient(c.GetChainGrpcClient(chainID))
+	_, err := client.Vote(ctx, &govv1beta1.QueryVoteRequest{
+		ProposalId: proposalID,
+		Voter:      voter,
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	if IsNotFound(err) {
+		return false, nil
+	}
+
+	return false, err
+}
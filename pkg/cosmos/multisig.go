@@ -0,0 +1,248 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	"github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// multisigSignMode is the only sign mode supported for multisig members signatures.
+const multisigSignMode = signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+
+var (
+	ErrInvalidThreshold  = errors.New("invalid multisig threshold")
+	ErrNotMultisigMember = errors.New("public key is not a member of multisig")
+	ErrInvalidSignature  = errors.New("invalid signature")
+)
+
+func (c *Client) CreateMultisigPubKey(threshold int, pubKeys []types.PubKey) (*kmultisig.LegacyAminoPubKey, error) {
+	if threshold <= 0 || threshold > len(pubKeys) {
+		err := fmt.Errorf("%w: %d of %d keys", ErrInvalidThreshold, threshold, len(pubKeys))
+		return nil, err
+	}
+
+	return kmultisig.NewLegacyAminoPubKey(threshold, pubKeys), nil
+}
+
+type UnsignedTransactionData struct {
+	ChainID     string
+	Memo        string
+	GasAdjusted string
+	GasPrice    string
+	Message     sdk.Msg
+}
+
+// CreateUnsignedTransaction returns json encoded unsigned transaction, which is
+// passed to every multisig member for signing.
+func (c *Client) CreateUnsignedTransaction(input UnsignedTransactionData) ([]byte, error) {
+	txFactory := c.newTxFactory(input.ChainID).WithSignMode(multisigSignMode)
+	if input.Memo != "" {
+		txFactory = txFactory.WithMemo(input.Memo)
+	}
+
+	adjusted, err := strconv.ParseUint(input.GasAdjusted, 0, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	txFactory = txFactory.WithGas(adjusted)
+	txFactory = txFactory.WithFees(input.GasPrice)
+
+	builder, err := txFactory.BuildUnsignedTx(input.Message)
+	if err != nil {
+		err = fmt.Errorf("build unsigned tx; %s", err.Error())
+		return nil, err
+	}
+
+	txBytes, err := c.txConfig.TxJSONEncoder()(builder.GetTx())
+	if err != nil {
+		err = fmt.Errorf("encode unsigned tx; %s", err.Error())
+		return nil, err
+	}
+
+	return txBytes, nil
+}
+
+type multisigTxContext struct {
+	builder    client.TxBuilder
+	signerData authsigning.SignerData
+}
+
+func (c *Client) getMultisigTxContext(ctx context.Context, chainID string, chainPrefix string, pubKey *kmultisig.LegacyAminoPubKey, txJSON []byte) (multisigTxContext, error) {
+	decodedTx, err := c.txConfig.TxJSONDecoder()(txJSON)
+	if err != nil {
+		err = fmt.Errorf("decode unsigned tx; %s", err.Error())
+		return multisigTxContext{}, err
+	}
+
+	builder, err := c.txConfig.WrapTxBuilder(decodedTx)
+	if err != nil {
+		err = fmt.Errorf("wrap unsigned tx; %s", err.Error())
+		return multisigTxContext{}, err
+	}
+
+	address, err := c.ConvertAddressPrefix(chainPrefix, pubKey.Address())
+	if err != nil {
+		return multisigTxContext{}, err
+	}
+
+	account, err := c.getAccount(ctx, address, chainID)
+	if err != nil {
+		return multisigTxContext{}, err
+	}
+
+	return multisigTxContext{
+		builder: builder,
+		signerData: authsigning.SignerData{
+			ChainID:       chainID,
+			AccountNumber: account.GetAccountNumber(),
+			Sequence:      account.GetSequence(),
+			PubKey:        pubKey,
+			Address:       address,
+		},
+	}, nil
+}
+
+type MultisigSignData struct {
+	ChainID        string
+	ChainPrefix    string
+	Key            string
+	MultisigPubKey *kmultisig.LegacyAminoPubKey
+	Tx             []byte
+}
+
+// SignMultisigTransaction returns partial signature of multisig member for unsigned transaction.
+func (c *Client) SignMultisigTransaction(ctx context.Context, input MultisigSignData) (types.PubKey, []byte, error) {
+	privateKey, err := c.CreateAccountFromHexKey(input.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !isMultisigMember(privateKey.PubKey(), input.MultisigPubKey) {
+		return nil, nil, ErrNotMultisigMember
+	}
+
+	txContext, err := c.getMultisigTxContext(ctx, input.ChainID, input.ChainPrefix, input.MultisigPubKey, input.Tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bytesToSign, err := c.txConfig.SignModeHandler().GetSignBytes(multisigSignMode, txContext.signerData, txContext.builder.GetTx())
+	if err != nil {
+		err = fmt.Errorf("get sign tx bytes; %s", err.Error())
+		return nil, nil, err
+	}
+
+	signature, err := privateKey.Sign(bytesToSign)
+	if err != nil {
+		err = fmt.Errorf("sign multisig tx; %s", err.Error())
+		return nil, nil, err
+	}
+
+	return privateKey.PubKey(), signature, nil
+}
+
+type PartialSignature struct {
+	PubKey    types.PubKey
+	Signature []byte
+}
+
+type MultisigCombineData struct {
+	ChainID        string
+	ChainPrefix    string
+	MultisigPubKey *kmultisig.LegacyAminoPubKey
+	Tx             []byte
+	Signatures     []PartialSignature
+}
+
+// CombineMultisigTransaction verifies partial signatures of multisig members
+// and assembles them into the signed transaction ready for broadcasting.
+func (c *Client) CombineMultisigTransaction(ctx context.Context, input MultisigCombineData) ([]byte, error) {
+	// signatures are counted by distinct members, so repeated signature of the same member doesn't reach the threshold
+	signatures := make([]PartialSignature, 0, len(input.Signatures))
+	signers := make(map[string]struct{}, len(input.Signatures))
+	for _, partial := range input.Signatures {
+		if !isMultisigMember(partial.PubKey, input.MultisigPubKey) {
+			err := fmt.Errorf("%w: %s", ErrNotMultisigMember, partial.PubKey.Address())
+			return nil, err
+		}
+
+		signer := partial.PubKey.Address().String()
+		if _, ok := signers[signer]; ok {
+			continue
+		}
+		signers[signer] = struct{}{}
+		signatures = append(signatures, partial)
+	}
+
+	if len(signatures) < int(input.MultisigPubKey.GetThreshold()) {
+		err := fmt.Errorf("%w: got %d distinct signers, need %d", ErrInvalidThreshold, len(signatures), input.MultisigPubKey.GetThreshold())
+		return nil, err
+	}
+
+	txContext, err := c.getMultisigTxContext(ctx, input.ChainID, input.ChainPrefix, input.MultisigPubKey, input.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	bytesToSign, err := c.txConfig.SignModeHandler().GetSignBytes(multisigSignMode, txContext.signerData, txContext.builder.GetTx())
+	if err != nil {
+		err = fmt.Errorf("get sign tx bytes; %s", err.Error())
+		return nil, err
+	}
+
+	pubKeys := input.MultisigPubKey.GetPubKeys()
+	multisigData := multisig.NewMultisig(len(pubKeys))
+	for _, partial := range signatures {
+		if !partial.PubKey.VerifySignature(bytesToSign, partial.Signature) {
+			err = fmt.Errorf("%w: signer %s", ErrInvalidSignature, partial.PubKey.Address())
+			return nil, err
+		}
+
+		sigData := &signing.SingleSignatureData{
+			SignMode:  multisigSignMode,
+			Signature: partial.Signature,
+		}
+		if err = multisig.AddSignatureFromPubKey(multisigData, sigData, partial.PubKey, pubKeys); err != nil {
+			err = fmt.Errorf("add multisig signature; %s", err.Error())
+			return nil, err
+		}
+	}
+
+	sig := signing.SignatureV2{
+		PubKey:   input.MultisigPubKey,
+		Data:     multisigData,
+		Sequence: txContext.signerData.Sequence,
+	}
+	if err = txContext.builder.SetSignatures(sig); err != nil {
+		err = fmt.Errorf("set tx signatures; %s", err.Error())
+		return nil, err
+	}
+
+	txBytes, err := c.txConfig.TxEncoder()(txContext.builder.GetTx())
+	if err != nil {
+		err = fmt.Errorf("get signed tx from builder; %s", err.Error())
+		return nil, err
+	}
+
+	return txBytes, nil
+}
+
+func isMultisigMember(pubKey types.PubKey, multisigPubKey *kmultisig.LegacyAminoPubKey) bool {
+	for _, member := range multisigPubKey.GetPubKeys() {
+		if member.Equals(pubKey) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// validatorsPageLimit is a page size of validators and signing infos queries.
+const validatorsPageLimit = 500
+
+// ValidatorInfo is a validator with its signing info, signing info is nil if it is not found or unavailable.
+type ValidatorInfo struct {
+	Validator   staking.Validator
+	SigningInfo *slashing.ValidatorSigningInfo
+}
+
+// GetValidators returns all validators with the status, or all validators if status is empty.
+// Signing infos are optional, so validators are returned without them if slashing query fails.
+func (c *Client) GetValidators(ctx context.Context, chainID string, status string) ([]ValidatorInfo, error) {
+	connection := c.GetChainGrpcClient(chainID)
+	stakingClient := staking.NewQueryClient(connection)
+
+	var validators []staking.Validator
+	var nextKey []byte
+	for {
+		response, err := stakingClient.Validators(ctx, &staking.QueryValidatorsRequest{
+			Status: status,
+			Pagination: &query.PageRequest{
+				Key:   nextKey,
+				Limit: validatorsPageLimit,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		validators = append(validators, response.Validators...)
+		if response.Pagination == nil || len(response.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = response.Pagination.NextKey
+	}
+
+	signingInfos, err := c.getSigningInfos(ctx, chainID)
+	if err != nil {
+		c.logger.Error(fmt.Errorf("chain: %s; signing infos; %s", chainID, err.Error()))
+	}
+
+	infoIndexes := make(map[string]int, len(signingInfos))
+	for index, info := range signingInfos {
+		if _, infoAddress, decodeErr := bech32.DecodeAndConvert(info.Address); decodeErr == nil {
+			infoIndexes[string(infoAddress)] = index
+		}
+	}
+
+	result := make([]ValidatorInfo, len(validators))
+	for index, validator := range validators {
+		result[index].Validator = validator
+		if err = validator.UnpackInterfaces(c.interfaceRegistry); err != nil {
+			continue
+		}
+
+		consAddress, consErr := validator.GetConsAddr()
+		if consErr != nil {
+			continue
+		}
+
+		if infoIndex, ok := infoIndexes[string(consAddress)]; ok {
+			result[index].SigningInfo = &signingInfos[infoIndex]
+		}
+	}
+
+	return result, nil
+}
+
+func (c *Client) getSigningInfos(ctx context.Context, chainID string) ([]slashing.ValidatorSigningInfo, error) {
+	slashingClient := slashing.NewQueryClient(c.GetChainGrpcClient(chainID))
+
+	var result []slashing.ValidatorSigningInfo
+	var nextKey []byte
+	for {
+		response, err := slashingClient.SigningInfos(ctx, &slashing.QuerySigningInfosRequest{
+			Pagination: &query.PageRequest{
+				Key:   nextKey,
+				Limit: validatorsPageLimit,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, response.Info...)
+		if response.Pagination == nil || len(response.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = response.Pagination.NextKey
+	}
+
+	return result, nil
+}
@@ -0,0 +1,189 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mobile-Web3/backend/pkg/cosmos/connection"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// txPollInterval is a period of polling tx status when its event has not been received.
+	txPollInterval = time.Second * 5
+	// txPollTimeout limits a single tx status request.
+	txPollTimeout = time.Second * 10
+	// mempoolPageLimit is a max count of unconfirmed txs returned by tendermint.
+	mempoolPageLimit = 100
+)
+
+var ErrInvalidTxHash = errors.New("invalid tx hash, expected 64 hex characters")
+
+// IsValidTxHash reports whether the hash is sha256 hex, so it is safe to put it into event query.
+func IsValidTxHash(txHash string) bool {
+	if len(txHash) != 64 {
+		return false
+	}
+
+	_, err := hex.DecodeString(txHash)
+	return err == nil
+}
+
+// TrackTx waits for the tx to be included into block until deadline and passes its result to the handler.
+// Tx is awaited by its hash with websocket subscription and polled in case events are missed or unavailable.
+// When deadline is already over, tx status is checked once. Tracking is stopped without result when ctx is done.
+func (c *Client) TrackTx(ctx context.Context, chainID string, txHash string, deadline time.Time, handler func(event connection.TxEvent)) error {
+	if !IsValidTxHash(txHash) {
+		return ErrInvalidTxHash
+	}
+
+	// tendermint matches tx.hash attribute as upper case hex string
+	txHash = strings.ToUpper(txHash)
+
+	var subscription *connection.Subscription
+	if timeout := time.Until(deadline); timeout > 0 {
+		query := fmt.Sprintf("tm.event = 'Tx' AND tx.hash = '%s'", txHash)
+		subscription, _ = c.GetChainWebsocketClient(chainID).Subscribe(ctx, query, timeout)
+	}
+
+	go c.waitTx(ctx, chainID, txHash, deadline, subscription, handler)
+	return nil
+}
+
+func (c *Client) waitTx(
+	ctx context.Context,
+	chainID string,
+	txHash string,
+	deadline time.Time,
+	subscription *connection.Subscription,
+	handler func(event connection.TxEvent)) {
+	deadlineTimer := time.NewTimer(time.Until(deadline))
+	defer deadlineTimer.Stop()
+	ticker := time.NewTicker(txPollInterval)
+	defer ticker.Stop()
+
+	var events <-chan connection.TxEvent
+	if subscription != nil {
+		defer subscription.Cancel()
+		events = subscriptionEvents(subscription)
+	}
+
+	event := connection.TxEvent{
+		TxHash: txHash,
+		Status: connection.TxStatusTimeout,
+	}
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case result, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			event = result
+			break loop
+		case <-ticker.C:
+			if result, found := c.getTxEvent(chainID, txHash); found {
+				event = result
+				break loop
+			}
+		case <-deadlineTimer.C:
+			// the last chance to find tx, which event could be missed
+			if result, found := c.getTxEvent(chainID, txHash); found {
+				event = result
+			}
+			break loop
+		}
+	}
+
+	handler(event)
+}
+
+// subscriptionEvents converts tx events of the subscription, channel is closed when subscription is done.
+func subscriptionEvents(subscription *connection.Subscription) <-chan connection.TxEvent {
+	result := make(chan connection.TxEvent, 1)
+	go func() {
+		defer close(result)
+		for {
+			select {
+			case <-subscription.Done():
+				return
+			case event := <-subscription.Events():
+				txData, ok := event.Data.(types.EventDataTx)
+				if !ok {
+					continue
+				}
+
+				txHash := ""
+				if len(event.Events["tx.hash"]) > 0 {
+					txHash = event.Events["tx.hash"][0]
+				}
+
+				result <- newTxEvent(txHash, txData.Height, txData.Result.Code, txData.Result.Log, txData.Result.Info, txData.Result.GasUsed, txData.Result.GasWanted)
+				return
+			}
+		}
+	}()
+	return result
+}
+
+func newTxEvent(txHash string, height int64, code uint32, log string, info string, gasUsed int64, gasWanted int64) connection.TxEvent {
+	status := connection.TxStatusSuccess
+	if code != 0 {
+		status = connection.TxStatusFailure
+	}
+
+	return connection.TxEvent{
+		TxHash:    txHash,
+		Status:    status,
+		Height:    height,
+		Code:      code,
+		Log:       log,
+		Info:      info,
+		GasUsed:   gasUsed,
+		GasWanted: gasWanted,
+	}
+}
+
+// getTxEvent returns result of the tx, if it is already included into block.
+// Errors are not returned, because tx is polled again until timeout.
+func (c *Client) getTxEvent(chainID string, txHash string) (connection.TxEvent, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), txPollTimeout)
+	defer cancel()
+
+	response, err := txtypes.NewServiceClient(c.GetChainGrpcClient(chainID)).GetTx(ctx, &txtypes.GetTxRequest{
+		Hash: txHash,
+	})
+	if err != nil || response.TxResponse == nil {
+		return connection.TxEvent{}, false
+	}
+
+	txResponse := response.TxResponse
+	return newTxEvent(txResponse.TxHash, txResponse.Height, txResponse.Code, txResponse.RawLog, txResponse.Info, txResponse.GasUsed, txResponse.GasWanted), true
+}
+
+// IsTxInMempool checks whether the tx is waiting in mempool. Only the first page of unconfirmed txs is checked,
+// because tendermint can't find mempool tx by hash.
+func (c *Client) IsTxInMempool(ctx context.Context, chainID string, txHash string) (bool, error) {
+	limit := mempoolPageLimit
+	response, err := c.GetChainHttpClient(chainID).UnconfirmedTxs(ctx, &limit)
+	if err != nil {
+		return false, err
+	}
+
+	for _, tx := range response.Txs {
+		if strings.EqualFold(fmt.Sprintf("%X", tx.Hash()), txHash) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
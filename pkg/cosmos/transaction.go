@@ -12,6 +12,7 @@ import (
 	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
 )
 
 func (c *Client) sign(key types.PrivKey, txf tx.Factory, txBuilder client.TxBuilder, overwriteSig bool) error {
@@ -117,7 +118,9 @@ type SendTransactionData struct {
 	GasPrice    string
 	ChainPrefix string
 	Key         string
-	Message     sdk.Msg
+	Messages    []sdk.Msg
+	// Sequence overrides sequence of the account, so several txs are signed before the previous ones are committed.
+	Sequence *uint64
 }
 
 func (c *Client) CreateSignedTransaction(ctx context.Context, input SendTransactionData) ([]byte, error) {
@@ -139,15 +142,16 @@ func (c *Client) CreateSignedTransaction(ctx context.Context, input SendTransact
 
 	txFactory = txFactory.WithGas(adjusted)
 	txFactory = txFactory.WithFees(input.GasPrice)
+	if input.Sequence != nil {
+		txFactory = txFactory.WithSequence(*input.Sequence)
+	}
 
-	builder, err := txFactory.BuildUnsignedTx(input.Message)
+	builder, err := txFactory.BuildUnsignedTx(input.Messages...)
 	if err != nil {
 		err = fmt.Errorf("build unsigned tx; %s", err.Error())
 		return nil, err
 	}
 
-	c.codec.MustMarshalJSON(input.Message)
-
 	if err = c.sign(txContext.PrivateKey, txFactory, builder, false); err != nil {
 		return nil, err
 	}
@@ -166,7 +170,7 @@ type SimulateTransactionData struct {
 	Memo        string
 	ChainPrefix string
 	Key         string
-	Message     sdk.Msg
+	Messages    []sdk.Msg
 }
 
 func (c *Client) CreateSimulateTransaction(ctx context.Context, input SimulateTransactionData) ([]byte, error) {
@@ -176,7 +180,7 @@ func (c *Client) CreateSimulateTransaction(ctx context.Context, input SimulateTr
 	}
 	factory := txContext.Factory
 
-	builder, err := factory.BuildUnsignedTx(input.Message)
+	builder, err := factory.BuildUnsignedTx(input.Messages...)
 	if err != nil {
 		err = fmt.Errorf("build unsigned tx; %s", err.Error())
 		return nil, err
@@ -209,3 +213,27 @@ func (c *Client) CreateSimulateTransaction(ctx context.Context, input SimulateTr
 
 	return txBytes, nil
 }
+
+// SimulateGas returns gas used by the transaction in simulation.
+func (c *Client) SimulateGas(ctx context.Context, input SimulateTransactionData) (uint64, error) {
+	txBytes, err := c.CreateSimulateTransaction(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := c.GetChainHttpClient(input.ChainID).ABCIQueryWithOptions(ctx, "/cosmos.tx.v1beta1.Service/Simulate", txBytes, rpcclient.ABCIQueryOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	if response.Response.Code != 0 {
+		return 0, fmt.Errorf("simulation failed with code %d; log: %s", response.Response.Code, response.Response.Log)
+	}
+
+	var result txtypes.SimulateResponse
+	if err = result.Unmarshal(response.Response.Value); err != nil {
+		return 0, err
+	}
+
+	return result.GasInfo.GasUsed, nil
+}
@@ -0,0 +1,131 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Mobile-Web3/backend/pkg/cosmos/connection"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	dbm "github.com/tendermint/tm-db"
+)
+
+var (
+	ErrAccountMismatch      = errors.New("account does not match verified state")
+	ErrInvalidTrustedHeader = errors.New("invalid trusted header, expected chainId:height:hash")
+)
+
+// ParseTrustedHeaders parses comma separated list of chainId:height:hash trusted headers.
+func ParseTrustedHeaders(value string) (map[string]connection.TrustedHeader, error) {
+	result := make(map[string]connection.TrustedHeader)
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTrustedHeader, item)
+		}
+
+		height, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil || height <= 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTrustedHeader, item)
+		}
+
+		hash, err := hex.DecodeString(parts[2])
+		if err != nil || len(hash) != tmhash.Size {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTrustedHeader, item)
+		}
+
+		result[parts[0]] = connection.TrustedHeader{
+			Height: height,
+			Hash:   hash,
+		}
+	}
+
+	return result, nil
+}
+
+// EnableVerification turns on light client verification of store queries for the chains,
+// verification starts from the trusted headers, later headers are kept in the db.
+func (c *Client) EnableVerification(db dbm.DB, trustedHeaders map[string]connection.TrustedHeader) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.lightDB = db
+	for chainID, trusted := range trustedHeaders {
+		c.verifiedChains[chainID] = trusted
+	}
+}
+
+func (c *Client) IsVerificationEnabled(chainID string) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	_, ok := c.verifiedChains[chainID]
+	return ok
+}
+
+// GetVerifiedBalance returns balance of the address proven against light client verified app hash
+// and height of the state.
+func (c *Client) GetVerifiedBalance(ctx context.Context, chainID string, address string, denom string) (sdk.Coin, int64, error) {
+	_, addressBytes, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return sdk.Coin{}, 0, err
+	}
+
+	key := append(banktypes.CreateAccountBalancesPrefix(addressBytes), []byte(denom)...)
+	value, height, err := c.getChainData(chainID).GrpcClient.QueryStore(ctx, banktypes.StoreKey, key)
+	if err != nil {
+		return sdk.Coin{}, 0, err
+	}
+
+	balance, err := bankkeeper.UnmarshalBalanceCompat(c.codec, value, denom)
+	return balance, height, err
+}
+
+// GetVerifiedAccount returns account proven against light client verified app hash.
+func (c *Client) GetVerifiedAccount(ctx context.Context, chainID string, address string) (authtypes.AccountI, error) {
+	_, addressBytes, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return nil, err
+	}
+
+	value, _, err := c.getChainData(chainID).GrpcClient.QueryStore(ctx, authtypes.StoreKey, authtypes.AddressStoreKey(addressBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return nil, fmt.Errorf("%w: account %s not found", ErrAccountMismatch, address)
+	}
+
+	var account authtypes.AccountI
+	if err = c.codec.UnmarshalInterface(value, &account); err != nil {
+		err = fmt.Errorf("unmarshal verified account; %s", err.Error())
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// verifyAccount checks account from the latest state with the verified one of the previous block,
+// sequence can only grow between them.
+func (c *Client) verifyAccount(ctx context.Context, chainID string, address string, account authtypes.AccountI) error {
+	verified, err := c.GetVerifiedAccount(ctx, chainID, address)
+	if err != nil {
+		return err
+	}
+
+	if verified.GetAccountNumber() != account.GetAccountNumber() || verified.GetSequence() > account.GetSequence() ||
+		(verified.GetPubKey() != nil && !verified.GetPubKey().Equals(account.GetPubKey())) {
+		return fmt.Errorf("%w: %s", ErrAccountMismatch, address)
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mobile-Web3/backend/pkg/cosmos/connection"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	// watchSubscriptionTimeout is a lifetime of transfer subscription, after that it is renewed.
+	watchSubscriptionTimeout = time.Hour * 24
+	// watchRetryInterval is a delay before subscribing again after failure.
+	watchRetryInterval = time.Second * 30
+	// watchQuery matches all txs, recipients are checked by the client, because tendermint can't match
+	// the set of addresses and every address would take its own subscription.
+	watchQuery = "tm.event = 'Tx'"
+)
+
+// WatchTransfers passes incoming transfers of the chain addresses, for which isWatched reports true,
+// to the handler until returned stop function is called. Bank transfers and IBC packets of all addresses
+// are received by the single subscription per chain. Subscription is renewed after failures,
+// so watching never stops by itself.
+func (c *Client) WatchTransfers(chainID string, isWatched func(address string) bool, handler func(event connection.TransferEvent)) func() {
+	stop := make(chan struct{})
+	go c.watchTransfers(chainID, isWatched, stop, handler)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stop)
+		})
+	}
+}
+
+func (c *Client) watchTransfers(chainID string, isWatched func(address string) bool, stop <-chan struct{}, handler func(event connection.TransferEvent)) {
+	for {
+		subscription, err := c.GetChainWebsocketClient(chainID).Subscribe(context.Background(), watchQuery, watchSubscriptionTimeout)
+		if err == nil {
+			receiveTransfers(subscription, isWatched, stop, handler)
+			subscription.Cancel()
+		}
+
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		// subscription has failed, expired one is renewed at once
+		if err != nil {
+			select {
+			case <-stop:
+				return
+			case <-time.After(watchRetryInterval):
+			}
+		}
+	}
+}
+
+// receiveTransfers handles events until subscription is over or watching is stopped.
+func receiveTransfers(
+	subscription *connection.Subscription,
+	isWatched func(address string) bool,
+	stop <-chan struct{},
+	handler func(event connection.TransferEvent)) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-subscription.Done():
+			return
+		case event := <-subscription.Events():
+			txData, ok := event.Data.(tmtypes.EventDataTx)
+			if !ok {
+				continue
+			}
+
+			txHash := fmt.Sprintf("%X", tmtypes.Tx(txData.Tx).Hash())
+			for _, recipient := range transferRecipients(txData.Result.Events) {
+				if !isWatched(recipient) {
+					continue
+				}
+
+				if transfer, found := newTransferEvent(recipient, txHash, txData.Height, txData.Result.Events); found {
+					handler(transfer)
+				}
+			}
+		}
+	}
+}
+
+// transferRecipients returns unique recipients of bank transfers and IBC packets of the tx.
+func transferRecipients(events []abci.Event) []string {
+	var result []string
+	recipients := make(map[string]struct{})
+	for _, event := range events {
+		key := ""
+		switch event.Type {
+		case "transfer":
+			key = "recipient"
+		case "fungible_token_packet":
+			key = "receiver"
+		default:
+			continue
+		}
+
+		for _, attribute := range event.Attributes {
+			recipient := string(attribute.Value)
+			if string(attribute.Key) != key || recipient == "" {
+				continue
+			}
+
+			if _, ok := recipients[recipient]; !ok {
+				recipients[recipient] = struct{}{}
+				result = append(result, recipient)
+			}
+		}
+	}
+	return result
+}
+
+// newTransferEvent sums coins transferred to the address by the tx, fees and other transfers are skipped.
+// payoutModuleAccounts are addresses of modules paying rewards and unbonded tokens to delegators.
+var payoutModuleAccounts = map[string]struct{}{
+	string(authtypes.NewModuleAddress(distrtypes.ModuleName)):          {},
+	string(authtypes.NewModuleAddress(stakingtypes.BondedPoolName)):    {},
+	string(authtypes.NewModuleAddress(stakingtypes.NotBondedPoolName)): {},
+}
+
+// isPayoutModuleAccount compares address bytes, because module accounts have the prefix of the chain.
+func isPayoutModuleAccount(address string) bool {
+	_, addressBytes, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return false
+	}
+
+	_, ok := payoutModuleAccounts[string(addressBytes)]
+	return ok
+}
+
+func newTransferEvent(address string, txHash string, height int64, events []abci.Event) (connection.TransferEvent, bool) {
+	result := connection.TransferEvent{
+		TxHash:    txHash,
+		Height:    height,
+		Recipient: address,
+	}
+
+	amount := types.NewCoins()
+	senders := make(map[string]struct{})
+	ibcSender, ibcChannel := "", ""
+	for _, event := range events {
+		attributes := make(map[string]string, len(event.Attributes))
+		for _, attribute := range event.Attributes {
+			attributes[string(attribute.Key)] = string(attribute.Value)
+		}
+
+		switch event.Type {
+		case "transfer":
+			// reward withdrawals and unbonding payouts are sent by module accounts, they are not incoming transfers
+			if attributes["recipient"] != address || isPayoutModuleAccount(attributes["sender"]) {
+				continue
+			}
+
+			coins, err := types.ParseCoinsNormalized(attributes["amount"])
+			if err != nil {
+				continue
+			}
+			amount = amount.Add(coins...)
+
+			sender := attributes["sender"]
+			if _, ok := senders[sender]; !ok && sender != "" {
+				senders[sender] = struct{}{}
+				result.Senders = append(result.Senders, sender)
+			}
+		case "recv_packet":
+			ibcChannel = attributes["packet_dst_channel"]
+		case "fungible_token_packet":
+			// the sender of IBC transfer is the account on counterparty chain, not the escrow or module account
+			if attributes["receiver"] == address && !strings.EqualFold(attributes["success"], "false") {
+				ibcSender = attributes["sender"]
+			}
+		}
+	}
+
+	if amount.IsZero() {
+		return connection.TransferEvent{}, false
+	}
+
+	if ibcSender != "" {
+		result.Senders = []string{ibcSender}
+		result.Channel = ibcChannel
+	}
+
+	result.Amount = amount.String()
+	return result, true
+}